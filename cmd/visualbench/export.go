@@ -0,0 +1,104 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Output formats for -format
+const (
+	formatTable = "table"
+	formatJSON  = "json"
+	formatCSV   = "csv"
+)
+
+// csvHeader is the stable column order of CSV exports.
+var csvHeader = []string{"algorithm", "time_ns", "vertices", "edges", "cores", "speedup",
+	"stddev_ns", "p50_ns", "p95_ns", "min_ns"}
+
+// ExportResult is the machine-readable form of a BenchmarkResult.
+type ExportResult struct {
+	Algorithm string  `json:"algorithm"`
+	TimeNs    int64   `json:"timeNs"`
+	Vertices  int     `json:"vertices"`
+	Edges     int     `json:"edges"`
+	Cores     int     `json:"cores"`
+	Speedup   float64 `json:"speedup"`
+	StdDevNs  int64   `json:"stdDevNs"`
+	P50Ns     int64   `json:"p50Ns"`
+	P95Ns     int64   `json:"p95Ns"`
+	MinNs     int64   `json:"minNs"`
+}
+
+func validFormat(format string) bool {
+	switch format {
+	case formatTable, formatJSON, formatCSV:
+		return true
+	}
+	return false
+}
+
+// exportResults converts results, computing speedup relative to the first entry.
+func exportResults(results []BenchmarkResult) []ExportResult {
+	exported := make([]ExportResult, len(results))
+	if len(results) == 0 {
+		return exported
+	}
+
+	baseline := results[0].Time
+	for i, r := range results {
+		exported[i] = ExportResult{
+			Algorithm: r.Algorithm,
+			TimeNs:    r.Time.Nanoseconds(),
+			Vertices:  r.Vertices,
+			Edges:     r.Edges,
+			Cores:     r.CoreCount,
+			Speedup:   float64(baseline) / float64(r.Time),
+			StdDevNs:  r.Stats.StdDev.Nanoseconds(),
+			P50Ns:     r.Stats.P50.Nanoseconds(),
+			P95Ns:     r.Stats.P95.Nanoseconds(),
+			MinNs:     r.Stats.Min.Nanoseconds(),
+		}
+	}
+	return exported
+}
+
+// writeResults serializes results to w as JSON or CSV.
+func writeResults(w io.Writer, results []BenchmarkResult, format string) error {
+	exported := exportResults(results)
+
+	switch format {
+	case formatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(exported)
+	case formatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(csvHeader); err != nil {
+			return err
+		}
+		for _, r := range exported {
+			record := []string{
+				r.Algorithm,
+				strconv.FormatInt(r.TimeNs, 10),
+				strconv.Itoa(r.Vertices),
+				strconv.Itoa(r.Edges),
+				strconv.Itoa(r.Cores),
+				strconv.FormatFloat(r.Speedup, 'f', 4, 64),
+				strconv.FormatInt(r.StdDevNs, 10),
+				strconv.FormatInt(r.P50Ns, 10),
+				strconv.FormatInt(r.P95Ns, 10),
+				strconv.FormatInt(r.MinNs, 10),
+			}
+			if err := cw.Write(record); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	}
+	return fmt.Errorf("unsupported format %q", format)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func sampleResults() []BenchmarkResult {
+	return []BenchmarkResult{
+		{Algorithm: "Duan", Time: 2 * time.Millisecond, Vertices: 100, Edges: 300, CoreCount: 4},
+		{Algorithm: "A*, heap", Time: 4 * time.Millisecond, Vertices: 100, Edges: 300, CoreCount: 4},
+	}
+}
+
+func TestWriteResultsCSV(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeResults(&buf, sampleResults(), formatCSV); err != nil {
+		t.Fatalf("writeResults: %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("reading CSV: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("got %d records, want header + 2", len(records))
+	}
+	for i, col := range csvHeader {
+		if records[0][i] != col {
+			t.Errorf("header column %d = %q, want %q", i, records[0][i], col)
+		}
+	}
+	if records[2][0] != "A*, heap" {
+		t.Errorf("algorithm = %q, want quoted name preserved", records[2][0])
+	}
+	if records[2][5] != "0.5000" {
+		t.Errorf("speedup = %q, want 0.5000", records[2][5])
+	}
+}
+
+func TestWriteResultsJSON(t *testing.T) {
+	var buf bytes.Buffer
+	if err := writeResults(&buf, sampleResults(), formatJSON); err != nil {
+		t.Fatalf("writeResults: %v", err)
+	}
+
+	var decoded []ExportResult
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("decoding JSON: %v", err)
+	}
+	if len(decoded) != 2 || decoded[0].TimeNs != 2000000 || decoded[0].Speedup != 1 {
+		t.Errorf("unexpected export: %+v", decoded)
+	}
+}
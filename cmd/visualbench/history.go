@@ -0,0 +1,157 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// HistoryEntry is one run as stored by -history, one JSON object per line.
+type HistoryEntry struct {
+	Time     time.Time      `json:"time"`
+	Describe string         `json:"describe,omitempty"` // git describe, when available
+	Vertices int            `json:"vertices"`
+	Edges    int            `json:"edges"`
+	Results  []ExportResult `json:"results"`
+}
+
+// HistoryDelta compares one algorithm's mean time with the previous entry.
+// Change is the relative difference in percent; positive means slower.
+type HistoryDelta struct {
+	Algorithm string
+	PrevNs    int64
+	CurNs     int64
+	Change    float64
+}
+
+func newHistoryEntry(results []BenchmarkResult, vertices, edges int) HistoryEntry {
+	return HistoryEntry{
+		Time:     time.Now().UTC(),
+		Describe: gitDescribe(),
+		Vertices: vertices,
+		Edges:    edges,
+		Results:  exportResults(results),
+	}
+}
+
+// gitDescribe returns `git describe --always --dirty` for the working
+// directory, or "" outside a repository or without git.
+func gitDescribe() string {
+	b, err := exec.Command("git", "describe", "--always", "--dirty").Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(b))
+}
+
+// appendHistory appends entry to the log at path, creating it if needed, and
+// returns the entry that was last in the file before, or nil if there was none.
+func appendHistory(path string, entry HistoryEntry) (*HistoryEntry, error) {
+	prev, err := lastHistoryEntry(path)
+	if err != nil {
+		return nil, err
+	}
+
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return prev, f.Close()
+}
+
+// lastHistoryEntry decodes the last non-empty line of the log at path. A
+// missing file is not an error.
+func lastHistoryEntry(path string) (*HistoryEntry, error) {
+	f, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var last []byte
+	sc := bufio.NewScanner(f)
+	sc.Buffer(nil, 1<<20)
+	for sc.Scan() {
+		if line := sc.Bytes(); len(bytes.TrimSpace(line)) > 0 {
+			last = append(last[:0], line...)
+		}
+	}
+	if err := sc.Err(); err != nil {
+		return nil, err
+	}
+	if last == nil {
+		return nil, nil
+	}
+
+	var e HistoryEntry
+	if err := json.Unmarshal(last, &e); err != nil {
+		return nil, fmt.Errorf("history %s: last entry: %w", path, err)
+	}
+	return &e, nil
+}
+
+// compareHistory matches algorithms by name; ones missing from prev, or with
+// a zero previous time, are skipped.
+func compareHistory(prev, cur HistoryEntry) []HistoryDelta {
+	prevNs := make(map[string]int64, len(prev.Results))
+	for _, r := range prev.Results {
+		prevNs[r.Algorithm] = r.TimeNs
+	}
+
+	var deltas []HistoryDelta
+	for _, r := range cur.Results {
+		p, ok := prevNs[r.Algorithm]
+		if !ok || p == 0 {
+			continue
+		}
+		deltas = append(deltas, HistoryDelta{
+			Algorithm: r.Algorithm,
+			PrevNs:    p,
+			CurNs:     r.TimeNs,
+			Change:    float64(r.TimeNs-p) / float64(p) * 100,
+		})
+	}
+	return deltas
+}
+
+// printHistoryComparison reports each algorithm's change since prev.
+func printHistoryComparison(w io.Writer, prev, cur HistoryEntry) {
+	fmt.Fprintf(w, "\n%sHistory: compared with %s", colorYellow, prev.Time.Format(time.RFC3339))
+	if prev.Describe != "" {
+		fmt.Fprintf(w, " (%s)", prev.Describe)
+	}
+	fmt.Fprintf(w, "%s\n", colorReset)
+	if prev.Vertices != cur.Vertices || prev.Edges != cur.Edges {
+		fmt.Fprintf(w, "  note: previous run used %d vertices, %d edges\n", prev.Vertices, prev.Edges)
+	}
+
+	for _, d := range compareHistory(prev, cur) {
+		color, word := colorGreen, "faster"
+		if d.Change > 0 {
+			color, word = colorRed, "slower"
+		}
+		change := d.Change
+		if change < 0 {
+			change = -change
+		}
+		fmt.Fprintf(w, "  %-30s %v → %v  %s%.1f%% %s%s\n", d.Algorithm,
+			time.Duration(d.PrevNs), time.Duration(d.CurNs), color, change, word, colorReset)
+	}
+}
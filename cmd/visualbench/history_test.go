@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAppendHistoryAndCompare(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+
+	first := newHistoryEntry(sampleResults(), 100, 300)
+	prev, err := appendHistory(path, first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prev != nil {
+		t.Fatalf("first append returned previous entry %+v", prev)
+	}
+
+	next := sampleResults()
+	next[0].Time = 3 * time.Millisecond // Duan 2ms -> 3ms
+	next[1].Time = 3 * time.Millisecond // A* 4ms -> 3ms
+	next = append(next, BenchmarkResult{Algorithm: "new", Time: time.Millisecond})
+	second := newHistoryEntry(next, 100, 300)
+
+	prev, err = appendHistory(path, second)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prev == nil || len(prev.Results) != 2 || prev.Results[0].TimeNs != 2000000 {
+		t.Fatalf("previous entry = %+v", prev)
+	}
+
+	deltas := compareHistory(*prev, second)
+	if len(deltas) != 2 {
+		t.Fatalf("got %d deltas, want 2 (new algorithm has no history): %+v", len(deltas), deltas)
+	}
+	if deltas[0].Algorithm != "Duan" || deltas[0].Change != 50 {
+		t.Errorf("Duan delta = %+v, want +50%%", deltas[0])
+	}
+	if deltas[1].Change != -25 {
+		t.Errorf("A* delta = %+v, want -25%%", deltas[1])
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Count(string(data), "\n"); lines != 2 {
+		t.Errorf("history has %d lines, want 2", lines)
+	}
+
+	last, err := lastHistoryEntry(path)
+	if err != nil || last == nil || len(last.Results) != 3 {
+		t.Errorf("lastHistoryEntry = %+v, %v", last, err)
+	}
+
+	var buf bytes.Buffer
+	printHistoryComparison(&buf, *prev, second)
+	if !strings.Contains(buf.String(), "50.0% slower") || !strings.Contains(buf.String(), "25.0% faster") {
+		t.Errorf("comparison output:\n%s", buf.String())
+	}
+}
+
+func TestLastHistoryEntryMissingFile(t *testing.T) {
+	e, err := lastHistoryEntry(filepath.Join(t.TempDir(), "none.jsonl"))
+	if e != nil || err != nil {
+		t.Errorf("missing file: %+v, %v", e, err)
+	}
+}
@@ -3,11 +3,13 @@ package main
 import (
 	"flag"
 	"fmt"
-	"math/rand"
+	"io"
+	"os"
 	"runtime"
 	"sync"
 	"time"
 
+	"github.com/phr3nzy/duan-sssp/gen"
 	"github.com/phr3nzy/duan-sssp/graph"
 	"github.com/phr3nzy/duan-sssp/sssp"
 )
@@ -25,9 +27,14 @@ const (
 	colorBold   = "\033[1m"
 )
 
+// out receives all human-readable output. It is redirected to stderr when
+// results are exported in a machine-readable format so stdout stays clean.
+var out io.Writer = os.Stdout
+
 type BenchmarkResult struct {
 	Algorithm string
-	Time      time.Duration
+	Time      time.Duration // Mean iteration time
+	Stats     DurationStats
 	Vertices  int
 	Edges     int
 	CoreCount int
@@ -37,13 +44,27 @@ func main() {
 	// Parse flags
 	vertices := flag.Int("vertices", 10000, "Number of vertices")
 	edgeFactor := flag.Int("edge-factor", 3, "Edges = vertices * edge-factor")
+	seed := flag.Int64("seed", 42, "Random seed for graph generation")
 	iterations := flag.Int("iterations", 10, "Number of benchmark iterations")
 	showGraph := flag.Bool("show-graph", true, "Show graph visualization")
 	parallel := flag.Bool("parallel", true, "Use all CPU cores")
 	web := flag.Bool("web", false, "Open web visualization in browser")
+	port := flag.Int("port", 8080, "Port for the web visualization server (0 picks a free port)")
+	format := flag.String("format", formatTable, "Output format: table, json or csv")
+	verify := flag.Bool("verify", true, "Check Duan and A* distances against naive Dijkstra")
+	tol := flag.Float64("tol", 1e-6, "Absolute tolerance for distance verification")
+	history := flag.String("history", "", "Append results as a JSON line to this file and compare with the previous entry")
 
 	flag.Parse()
 
+	if !validFormat(*format) {
+		fmt.Fprintf(os.Stderr, "unknown -format %q (want table, json or csv)\n", *format)
+		os.Exit(2)
+	}
+	if *format != formatTable {
+		out = os.Stderr
+	}
+
 	edges := (*vertices) * (*edgeFactor)
 
 	// Configure runtime
@@ -56,33 +77,44 @@ func main() {
 	printHeader(*vertices, edges, *iterations)
 
 	// Generate graph
-	fmt.Printf("%s[1/4] Generating random graph...%s\n", colorCyan, colorReset)
-	g := generateGraph(*vertices, edges)
+	fmt.Fprintf(out, "%s[1/4] Generating random graph...%s\n", colorCyan, colorReset)
+	g := gen.Random(*vertices, edges, *seed)
 
 	if *showGraph {
 		visualizeGraph(g, 20) // Show sample of 20 vertices
 	}
 
+	if *verify {
+		fmt.Fprintf(out, "\n%s[Verify] Comparing distances against naive Dijkstra...%s\n", colorCyan, colorReset)
+		if err := verifyDistances(g, 0, *tol); err != nil {
+			fmt.Fprintf(os.Stderr, "%s%sVERIFICATION FAILED: %v%s\n", colorBold, colorRed, err, colorReset)
+			os.Exit(1)
+		}
+		fmt.Fprintf(out, "  %s✓%s All algorithms agree (tol=%g)\n", colorGreen, colorReset, *tol)
+	}
+
 	// Run benchmarks
-	fmt.Printf("\n%s[2/4] Running benchmarks with %d cores...%s\n", colorCyan, runtime.GOMAXPROCS(0), colorReset)
+	fmt.Fprintf(out, "\n%s[2/4] Running benchmarks with %d cores...%s\n", colorCyan, runtime.GOMAXPROCS(0), colorReset)
 
 	results := make([]BenchmarkResult, 0)
 
 	// Duan Algorithm
-	duanTime := benchmarkDuan(g, *iterations)
+	duanStats := benchmarkDuan(g, *iterations)
 	results = append(results, BenchmarkResult{
 		Algorithm: "Duan (O(m log^(2/3) n))",
-		Time:      duanTime,
+		Time:      duanStats.Mean,
+		Stats:     duanStats,
 		Vertices:  *vertices,
 		Edges:     edges,
 		CoreCount: runtime.GOMAXPROCS(0),
 	})
 
 	// A* Algorithm
-	astarTime := benchmarkAStar(g, *iterations)
+	astarStats := benchmarkAStar(g, *iterations)
 	results = append(results, BenchmarkResult{
 		Algorithm: "A* with Heap",
-		Time:      astarTime,
+		Time:      astarStats.Mean,
+		Stats:     astarStats,
 		Vertices:  *vertices,
 		Edges:     edges,
 		CoreCount: runtime.GOMAXPROCS(0),
@@ -90,65 +122,70 @@ func main() {
 
 	// Parallel Duan (if requested)
 	if *parallel && runtime.NumCPU() > 1 {
-		parallelTime := benchmarkParallelMultiSource(g, *iterations)
+		parallelStats, err := benchmarkParallelMultiSource(g, *iterations)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "parallel benchmark: %v\n", err)
+			os.Exit(1)
+		}
 		results = append(results, BenchmarkResult{
 			Algorithm: fmt.Sprintf("Duan Parallel (%d cores)", runtime.NumCPU()),
-			Time:      parallelTime,
+			Time:      parallelStats.Mean,
+			Stats:     parallelStats,
 			Vertices:  *vertices,
 			Edges:     edges,
 			CoreCount: runtime.GOMAXPROCS(0),
 		})
 	}
 
-	// Display results
-	fmt.Printf("\n%s[3/4] Results:%s\n", colorCyan, colorReset)
-	displayResults(results)
+	if *format != formatTable {
+		if err := writeResults(os.Stdout, results, *format); err != nil {
+			fmt.Fprintf(os.Stderr, "writing results: %v\n", err)
+			os.Exit(1)
+		}
+	} else {
+		// Display results
+		fmt.Fprintf(out, "\n%s[3/4] Results:%s\n", colorCyan, colorReset)
+		displayResults(results)
+
+		// Visualize performance
+		fmt.Fprintf(out, "\n%s[4/4] Performance Visualization:%s\n", colorCyan, colorReset)
+		visualizePerformance(results)
 
-	// Visualize performance
-	fmt.Printf("\n%s[4/4] Performance Visualization:%s\n", colorCyan, colorReset)
-	visualizePerformance(results)
+		printSummary(results)
+	}
 
-	printSummary(results)
+	if *history != "" {
+		entry := newHistoryEntry(results, *vertices, edges)
+		prev, err := appendHistory(*history, entry)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "writing history: %v\n", err)
+			os.Exit(1)
+		}
+		if prev != nil {
+			printHistoryComparison(out, *prev, entry)
+		}
+	}
 
 	// Web visualization
 	if *web {
-		fmt.Printf("\n%s[Bonus] Creating web visualization...%s\n", colorCyan, colorReset)
-		startWebVisualization(g, results)
-		fmt.Printf("\n%sPress Ctrl+C to exit...%s\n", colorYellow, colorReset)
-		select {} // Keep server running
+		fmt.Fprintf(out, "\n%s[Bonus] Creating web visualization...%s\n", colorCyan, colorReset)
+		startWebVisualization(g, results, *port)
 	}
 }
 
 func printHeader(vertices, edges, iterations int) {
-	fmt.Printf("\n")
-	fmt.Printf("%s╔════════════════════════════════════════════════════════════╗%s\n", colorBold+colorBlue, colorReset)
-	fmt.Printf("%s║          DUAN SSSP VISUAL BENCHMARK SUITE                  ║%s\n", colorBold+colorBlue, colorReset)
-	fmt.Printf("%s║     Breaking the Sorting Barrier - O(m log^(2/3) n)       ║%s\n", colorBold+colorBlue, colorReset)
-	fmt.Printf("%s╚════════════════════════════════════════════════════════════╝%s\n", colorBold+colorBlue, colorReset)
-	fmt.Printf("\n")
-	fmt.Printf("%sConfiguration:%s\n", colorYellow, colorReset)
-	fmt.Printf("  Vertices:   %s%d%s\n", colorBold, vertices, colorReset)
-	fmt.Printf("  Edges:      %s%d%s (%.1fx density)\n", colorBold, edges, colorReset, float64(edges)/float64(vertices))
-	fmt.Printf("  Iterations: %s%d%s\n", colorBold, iterations, colorReset)
-	fmt.Printf("  CPU Cores:  %s%d%s / %d available\n", colorBold, runtime.GOMAXPROCS(0), colorReset, runtime.NumCPU())
-	fmt.Printf("\n")
-}
-
-func generateGraph(vertices, edges int) *graph.Graph {
-	g := graph.NewGraph(vertices)
-	rng := rand.New(rand.NewSource(42)) // Deterministic for reproducibility
-
-	for i := 0; i < edges; i++ {
-		u := rng.Intn(vertices)
-		v := rng.Intn(vertices)
-		if u == v {
-			v = (v + 1) % vertices
-		}
-		w := rng.Float64()*100.0 + 1.0
-		g.AddEdge(u, v, w)
-	}
-
-	return g
+	fmt.Fprintf(out, "\n")
+	fmt.Fprintf(out, "%s╔════════════════════════════════════════════════════════════╗%s\n", colorBold+colorBlue, colorReset)
+	fmt.Fprintf(out, "%s║          DUAN SSSP VISUAL BENCHMARK SUITE                  ║%s\n", colorBold+colorBlue, colorReset)
+	fmt.Fprintf(out, "%s║     Breaking the Sorting Barrier - O(m log^(2/3) n)       ║%s\n", colorBold+colorBlue, colorReset)
+	fmt.Fprintf(out, "%s╚════════════════════════════════════════════════════════════╝%s\n", colorBold+colorBlue, colorReset)
+	fmt.Fprintf(out, "\n")
+	fmt.Fprintf(out, "%sConfiguration:%s\n", colorYellow, colorReset)
+	fmt.Fprintf(out, "  Vertices:   %s%d%s\n", colorBold, vertices, colorReset)
+	fmt.Fprintf(out, "  Edges:      %s%d%s (%.1fx density)\n", colorBold, edges, colorReset, float64(edges)/float64(vertices))
+	fmt.Fprintf(out, "  Iterations: %s%d%s\n", colorBold, iterations, colorReset)
+	fmt.Fprintf(out, "  CPU Cores:  %s%d%s / %d available\n", colorBold, runtime.GOMAXPROCS(0), colorReset, runtime.NumCPU())
+	fmt.Fprintf(out, "\n")
 }
 
 func visualizeGraph(g *graph.Graph, sampleSize int) {
@@ -156,83 +193,90 @@ func visualizeGraph(g *graph.Graph, sampleSize int) {
 		sampleSize = g.V
 	}
 
-	fmt.Printf("\n%sGraph Structure (sample %d/%d vertices):%s\n", colorYellow, sampleSize, g.V, colorReset)
-	fmt.Printf("┌─────┬─────────────────────────────────────┐\n")
-	fmt.Printf("│ %sV%s   │ %sEdges (to → weight)%s              │\n", colorBold, colorReset, colorBold, colorReset)
-	fmt.Printf("├─────┼─────────────────────────────────────┤\n")
+	fmt.Fprintf(out, "\n%sGraph Structure (sample %d/%d vertices):%s\n", colorYellow, sampleSize, g.V, colorReset)
+	fmt.Fprintf(out, "┌─────┬─────────────────────────────────────┐\n")
+	fmt.Fprintf(out, "│ %sV%s   │ %sEdges (to → weight)%s              │\n", colorBold, colorReset, colorBold, colorReset)
+	fmt.Fprintf(out, "├─────┼─────────────────────────────────────┤\n")
 
 	for i := 0; i < sampleSize && i < g.V; i++ {
-		fmt.Printf("│ %3d │ ", i)
+		fmt.Fprintf(out, "│ %3d │ ", i)
 
 		edgeCount := len(g.Adj[i])
 		if edgeCount == 0 {
-			fmt.Printf("(isolated)")
+			fmt.Fprintf(out, "(isolated)")
 		} else {
 			for j, edge := range g.Adj[i] {
 				if j >= 3 {
-					fmt.Printf("... +%d more", edgeCount-3)
+					fmt.Fprintf(out, "... +%d more", edgeCount-3)
 					break
 				}
-				fmt.Printf("%d→%.1f ", edge.To, edge.Weight)
+				fmt.Fprintf(out, "%d→%.1f ", edge.To, edge.Weight)
 			}
 		}
-		fmt.Printf("%s\n", colorReset)
+		fmt.Fprintf(out, "%s\n", colorReset)
 	}
 
 	if sampleSize < g.V {
-		fmt.Printf("│ ... │ ... (%d more vertices)              │\n", g.V-sampleSize)
+		fmt.Fprintf(out, "│ ... │ ... (%d more vertices)              │\n", g.V-sampleSize)
 	}
-	fmt.Printf("└─────┴─────────────────────────────────────┘\n")
+	fmt.Fprintf(out, "└─────┴─────────────────────────────────────┘\n")
 }
 
-func benchmarkDuan(g *graph.Graph, iterations int) time.Duration {
-	fmt.Printf("  %s►%s Duan Algorithm...", colorGreen, colorReset)
+// benchmarkDuan times iterations runs of one solver after Solver.Warmup.
+func benchmarkDuan(g *graph.Graph, iterations int) DurationStats {
+	fmt.Fprintf(out, "  %s►%s Duan Algorithm...", colorGreen, colorReset)
 
-	var totalTime time.Duration
-
-	for i := 0; i < iterations; i++ {
-		tg := g.ToConstantDegree()
-		solver := sssp.NewSolver(tg.G)
+	samples := make([]time.Duration, 0, iterations)
+	tg := g.ToConstantDegree()
+	solver := sssp.NewSolver(tg.G)
+	solver.Warmup(tg.OriginalTo[0])
 
+	for i := 1; i <= iterations; i++ {
 		start := time.Now()
 		solver.Run(tg.OriginalTo[0])
-		totalTime += time.Since(start)
+		samples = append(samples, time.Since(start))
 
 		// Progress indicator
 		if i%max(iterations/10, 1) == 0 {
-			fmt.Printf(".")
+			fmt.Fprintf(out, ".")
 		}
 	}
 
-	avgTime := totalTime / time.Duration(iterations)
-	fmt.Printf(" %s✓%s %v\n", colorGreen, colorReset, avgTime)
+	stats := computeStats(samples)
+	fmt.Fprintf(out, " %s✓%s %v ± %v\n", colorGreen, colorReset, stats.Mean, stats.StdDev)
 
-	return avgTime
+	return stats
 }
 
-func benchmarkAStar(g *graph.Graph, iterations int) time.Duration {
-	fmt.Printf("  %s►%s A* Algorithm...", colorYellow, colorReset)
+// benchmarkAStar times iterations runs after one discarded warmup run.
+func benchmarkAStar(g *graph.Graph, iterations int) DurationStats {
+	fmt.Fprintf(out, "  %s►%s A* Algorithm...", colorYellow, colorReset)
 
-	var totalTime time.Duration
+	samples := make([]time.Duration, 0, iterations)
 
-	for i := 0; i < iterations; i++ {
+	for i := 0; i <= iterations; i++ {
 		start := time.Now()
 		aStarSSSP(g, 0)
-		totalTime += time.Since(start)
+		elapsed := time.Since(start)
+
+		if i == 0 {
+			continue // warmup
+		}
+		samples = append(samples, elapsed)
 
 		if i%max(iterations/10, 1) == 0 {
-			fmt.Printf(".")
+			fmt.Fprintf(out, ".")
 		}
 	}
 
-	avgTime := totalTime / time.Duration(iterations)
-	fmt.Printf(" %s✓%s %v\n", colorYellow, colorReset, avgTime)
+	stats := computeStats(samples)
+	fmt.Fprintf(out, " %s✓%s %v ± %v\n", colorYellow, colorReset, stats.Mean, stats.StdDev)
 
-	return avgTime
+	return stats
 }
 
 func benchmarkParallelDuan(g *graph.Graph, iterations int) time.Duration {
-	fmt.Printf("  %s►%s Duan Parallel (%d cores)...", colorPurple, colorReset, runtime.NumCPU())
+	fmt.Fprintf(out, "  %s►%s Duan Parallel (%d cores)...", colorPurple, colorReset, runtime.NumCPU())
 
 	numCores := runtime.NumCPU()
 	var totalTime time.Duration
@@ -262,20 +306,20 @@ func benchmarkParallelDuan(g *graph.Graph, iterations int) time.Duration {
 		totalTime += time.Since(start)
 
 		if i%max(iterations/10, 1) == 0 {
-			fmt.Printf(".")
+			fmt.Fprintf(out, ".")
 		}
 	}
 
 	avgTime := totalTime / time.Duration(iterations)
-	fmt.Printf(" %s✓%s %v\n", colorPurple, colorReset, avgTime)
+	fmt.Fprintf(out, " %s✓%s %v\n", colorPurple, colorReset, avgTime)
 
 	return avgTime
 }
 
 func displayResults(results []BenchmarkResult) {
-	fmt.Printf("\n┌────────────────────────────┬────────────────┬─────────────┐\n")
-	fmt.Printf("│ %sAlgorithm%s                  │ %sAvg Time%s       │ %sSpeedup%s     │\n", colorBold, colorReset, colorBold, colorReset, colorBold, colorReset)
-	fmt.Printf("├────────────────────────────┼────────────────┼─────────────┤\n")
+	fmt.Fprintf(out, "\n┌────────────────────────────┬──────────────────────────┬─────────────┬─────────────┐\n")
+	fmt.Fprintf(out, "│ %sAlgorithm%s                  │ %sMean ± StdDev%s            │ %sP95%s         │ %sSpeedup%s     │\n", colorBold, colorReset, colorBold, colorReset, colorBold, colorReset, colorBold, colorReset)
+	fmt.Fprintf(out, "├────────────────────────────┼──────────────────────────┼─────────────┼─────────────┤\n")
 
 	baseline := results[0].Time
 
@@ -288,13 +332,14 @@ func displayResults(results []BenchmarkResult) {
 			color = colorYellow
 		}
 
-		fmt.Printf("│ %-26s │ %s%14v%s │ %s%11.2fx%s │\n",
+		fmt.Fprintf(out, "│ %-26s │ %s%24s%s │ %11v │ %s%11.2fx%s │\n",
 			r.Algorithm,
-			color, r.Time, colorReset,
+			color, fmt.Sprintf("%v ± %v", r.Time, r.Stats.StdDev), colorReset,
+			r.Stats.P95,
 			color, speedup, colorReset)
 	}
 
-	fmt.Printf("└────────────────────────────┴────────────────┴─────────────┘\n")
+	fmt.Fprintf(out, "└────────────────────────────┴──────────────────────────┴─────────────┴─────────────┘\n")
 }
 
 func visualizePerformance(results []BenchmarkResult) {
@@ -312,7 +357,7 @@ func visualizePerformance(results []BenchmarkResult) {
 
 	// Draw bars
 	barWidth := 50
-	fmt.Printf("\n")
+	fmt.Fprintf(out, "\n")
 
 	for _, r := range results {
 		barLen := int(float64(r.Time) / float64(maxTime) * float64(barWidth))
@@ -329,18 +374,18 @@ func visualizePerformance(results []BenchmarkResult) {
 			color = colorRed
 		}
 
-		fmt.Printf("%-26s %s", r.Algorithm, color)
+		fmt.Fprintf(out, "%-26s %s", r.Algorithm, color)
 		for i := 0; i < barLen; i++ {
-			fmt.Printf("█")
+			fmt.Fprintf(out, "█")
 		}
-		fmt.Printf("%s %v\n", colorReset, r.Time)
+		fmt.Fprintf(out, "%s %v\n", colorReset, r.Time)
 	}
 
-	fmt.Printf("\n%sScale: 0%s", colorBold, colorReset)
+	fmt.Fprintf(out, "\n%sScale: 0%s", colorBold, colorReset)
 	for i := 0; i < barWidth-10; i++ {
-		fmt.Printf(" ")
+		fmt.Fprintf(out, " ")
 	}
-	fmt.Printf("%s%v%s\n", colorBold, maxTime, colorReset)
+	fmt.Fprintf(out, "%s%v%s\n", colorBold, maxTime, colorReset)
 }
 
 func printSummary(results []BenchmarkResult) {
@@ -348,21 +393,21 @@ func printSummary(results []BenchmarkResult) {
 		return
 	}
 
-	fmt.Printf("\n%s╔════════════════════════════════════════════════════════════╗%s\n", colorBold+colorGreen, colorReset)
-	fmt.Printf("%s║                         SUMMARY                            ║%s\n", colorBold+colorGreen, colorReset)
-	fmt.Printf("%s╚════════════════════════════════════════════════════════════╝%s\n", colorBold+colorGreen, colorReset)
+	fmt.Fprintf(out, "\n%s╔════════════════════════════════════════════════════════════╗%s\n", colorBold+colorGreen, colorReset)
+	fmt.Fprintf(out, "%s║                         SUMMARY                            ║%s\n", colorBold+colorGreen, colorReset)
+	fmt.Fprintf(out, "%s╚════════════════════════════════════════════════════════════╝%s\n", colorBold+colorGreen, colorReset)
 
 	duanTime := results[0].Time
 	astarTime := results[1].Time
 
 	speedup := float64(astarTime) / float64(duanTime)
 
-	fmt.Printf("\n%s★ Duan algorithm is %.1fx faster than A* (heap)%s\n", colorBold+colorGreen, speedup, colorReset)
+	fmt.Fprintf(out, "\n%s★ Duan algorithm is %.1fx faster than A* (heap)%s\n", colorBold+colorGreen, speedup, colorReset)
 
 	if len(results) > 2 {
 		parallelTime := results[2].Time
 		parallelSpeedup := float64(duanTime) / float64(parallelTime)
-		fmt.Printf("%s★ Parallel version (%d cores) is %.1fx faster%s\n",
+		fmt.Fprintf(out, "%s★ Parallel version (%d cores) is %.1fx faster%s\n",
 			colorBold+colorPurple, runtime.NumCPU(), parallelSpeedup, colorReset)
 	}
 
@@ -370,16 +415,16 @@ func printSummary(results []BenchmarkResult) {
 	perVertex := float64(duanTime.Nanoseconds()) / float64(results[0].Vertices)
 	perEdge := float64(duanTime.Nanoseconds()) / float64(results[0].Edges)
 
-	fmt.Printf("\n%sPerformance Metrics:%s\n", colorYellow, colorReset)
-	fmt.Printf("  Per-vertex time: %.2f ns\n", perVertex)
-	fmt.Printf("  Per-edge time:   %.2f ns\n", perEdge)
-	fmt.Printf("  Throughput:      %.2f M vertices/sec\n", 1000.0/perVertex)
+	fmt.Fprintf(out, "\n%sPerformance Metrics:%s\n", colorYellow, colorReset)
+	fmt.Fprintf(out, "  Per-vertex time: %.2f ns\n", perVertex)
+	fmt.Fprintf(out, "  Per-edge time:   %.2f ns\n", perEdge)
+	fmt.Fprintf(out, "  Throughput:      %.2f M vertices/sec\n", 1000.0/perVertex)
 
-	fmt.Printf("\n%sCPU Utilization:%s\n", colorYellow, colorReset)
-	fmt.Printf("  Cores used:      %d / %d available\n", runtime.GOMAXPROCS(0), runtime.NumCPU())
-	fmt.Printf("  Parallelization: %s\n", map[bool]string{true: "Enabled", false: "Disabled"}[runtime.GOMAXPROCS(0) > 1])
+	fmt.Fprintf(out, "\n%sCPU Utilization:%s\n", colorYellow, colorReset)
+	fmt.Fprintf(out, "  Cores used:      %d / %d available\n", runtime.GOMAXPROCS(0), runtime.NumCPU())
+	fmt.Fprintf(out, "  Parallelization: %s\n", map[bool]string{true: "Enabled", false: "Disabled"}[runtime.GOMAXPROCS(0) > 1])
 
-	fmt.Printf("\n")
+	fmt.Fprintf(out, "\n")
 }
 
 // Simple A* implementation for comparison
@@ -400,10 +445,12 @@ func aStarSSSP(g *graph.Graph, source int) []float64 {
 	visited := make([]bool, g.V)
 
 	for len(pq) > 0 {
-		// Extract min (simple linear search for benchmark)
+		// Extract min (simple linear search for benchmark), breaking score
+		// ties on the vertex like sssp.PriorityQueue
 		minIdx := 0
 		for i := 1; i < len(pq); i++ {
-			if pq[i].score < pq[minIdx].score {
+			a, b := pq[i], pq[minIdx]
+			if a.score < b.score || a.score == b.score && a.v < b.v {
 				minIdx = i
 			}
 		}
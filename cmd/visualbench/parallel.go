@@ -2,46 +2,42 @@ package main
 
 import (
 	"runtime"
-	"sync"
 	"time"
 
 	"github.com/phr3nzy/duan-sssp/graph"
 	"github.com/phr3nzy/duan-sssp/sssp"
 )
 
-// ParallelBenchmarkDuan runs Duan algorithm with multiple sources in parallel
-func benchmarkParallelMultiSource(g *graph.Graph, iterations int) time.Duration {
+// benchmarkParallelMultiSource times iterations parallel multi-source runs,
+// one solver per core, and summarizes the per-iteration samples. The
+// transform and sources are built once, and a first untimed run is discarded
+// as warmup like the other rows.
+func benchmarkParallelMultiSource(g *graph.Graph, iterations int) (DurationStats, error) {
 	numCores := runtime.NumCPU()
-	var totalTime time.Duration
+	samples := make([]time.Duration, 0, iterations)
 
-	for iter := 0; iter < iterations; iter++ {
-		tg := g.ToConstantDegree()
+	tg := g.ToConstantDegree()
 
-		// Select sources spread across the graph
-		sources := make([]int, min(numCores*2, g.V))
-		for i := range sources {
-			sources[i] = (i * g.V) / len(sources)
-		}
+	// Select sources spread across the graph
+	sources := make([]int, min(numCores*2, g.V))
+	for i := range sources {
+		sources[i] = (i * g.V) / len(sources)
+	}
 
+	for i := 0; i <= iterations; i++ {
 		start := time.Now()
 
-		// Run SSSP from multiple sources in parallel
-		var wg sync.WaitGroup
-		results := make([][]float64, len(sources))
-
-		for idx, src := range sources {
-			wg.Add(1)
-			go func(i, source int) {
-				defer wg.Done()
-				solver := sssp.NewSolver(tg.G)
-				rawDist := solver.Run(tg.OriginalTo[source])
-				results[i] = tg.MapDistances(rawDist)
-			}(idx, src)
+		// Run SSSP from multiple sources in parallel, one solver per core
+		if _, err := sssp.MultiSourceParallel(tg, sources, numCores); err != nil {
+			return DurationStats{}, err
 		}
+		elapsed := time.Since(start)
 
-		wg.Wait()
-		totalTime += time.Since(start)
+		if i == 0 {
+			continue // warmup
+		}
+		samples = append(samples, elapsed)
 	}
 
-	return totalTime / time.Duration(iterations)
+	return computeStats(samples), nil
 }
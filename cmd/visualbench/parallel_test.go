@@ -0,0 +1,19 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/gen"
+)
+
+// TestBenchmarkParallelMultiSourceStats checks that the parallel row gets
+// statistics computed from real samples, not just a mean.
+func TestBenchmarkParallelMultiSourceStats(t *testing.T) {
+	stats, err := benchmarkParallelMultiSource(gen.Random(200, 800, 1), 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.Min <= 0 || stats.P50 < stats.Min || stats.P95 < stats.P50 {
+		t.Errorf("stats not computed from samples: %+v", stats)
+	}
+}
@@ -0,0 +1,58 @@
+package main
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// DurationStats summarizes per-iteration timings.
+type DurationStats struct {
+	Mean   time.Duration
+	StdDev time.Duration
+	P50    time.Duration
+	P95    time.Duration
+	Min    time.Duration
+}
+
+// computeStats returns mean, population standard deviation, nearest-rank
+// percentiles and minimum of samples. samples is not modified.
+func computeStats(samples []time.Duration) DurationStats {
+	if len(samples) == 0 {
+		return DurationStats{}
+	}
+
+	sorted := make([]time.Duration, len(samples))
+	copy(sorted, samples)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var sum float64
+	for _, d := range sorted {
+		sum += float64(d)
+	}
+	mean := sum / float64(len(sorted))
+
+	var sq float64
+	for _, d := range sorted {
+		diff := float64(d) - mean
+		sq += diff * diff
+	}
+	stddev := math.Sqrt(sq / float64(len(sorted)))
+
+	return DurationStats{
+		Mean:   time.Duration(mean),
+		StdDev: time.Duration(stddev),
+		P50:    percentile(sorted, 0.50),
+		P95:    percentile(sorted, 0.95),
+		Min:    sorted[0],
+	}
+}
+
+// percentile uses the nearest-rank method on an ascending slice.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestComputeStats(t *testing.T) {
+	samples := []time.Duration{
+		9 * time.Millisecond,
+		2 * time.Millisecond,
+		4 * time.Millisecond,
+		4 * time.Millisecond,
+		5 * time.Millisecond,
+		5 * time.Millisecond,
+		7 * time.Millisecond,
+		4 * time.Millisecond,
+	}
+
+	got := computeStats(samples)
+	want := DurationStats{
+		Mean:   5 * time.Millisecond,
+		StdDev: 2 * time.Millisecond,
+		P50:    4 * time.Millisecond,
+		P95:    9 * time.Millisecond,
+		Min:    2 * time.Millisecond,
+	}
+	if got != want {
+		t.Errorf("computeStats = %+v, want %+v", got, want)
+	}
+
+	if samples[0] != 9*time.Millisecond {
+		t.Error("computeStats reordered its input")
+	}
+}
+
+func TestComputeStatsEmpty(t *testing.T) {
+	if got := computeStats(nil); got != (DurationStats{}) {
+		t.Errorf("computeStats(nil) = %+v, want zero", got)
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/phr3nzy/duan-sssp/graph"
+	"github.com/phr3nzy/duan-sssp/sssp"
+)
+
+// verifyDistances runs Duan, A* and naive Dijkstra from source and reports
+// the first vertex on which any of them disagrees with the naive reference.
+func verifyDistances(g *graph.Graph, source int, tol float64) error {
+	tg := g.ToConstantDegree()
+	solver := sssp.NewSolver(tg.G)
+	duan := tg.MapDistances(solver.Run(tg.OriginalTo[source]))
+	astar := aStarSSSP(g, source)
+	reference := naiveDijkstra(g, source)
+
+	if err := compareDistances("Duan", duan, reference, tol); err != nil {
+		return err
+	}
+	return compareDistances("A*", astar, reference, tol)
+}
+
+// compareDistances checks got against want element-wise within tol.
+// Unreachable vertices must be unreachable in both.
+func compareDistances(name string, got, want []float64, tol float64) error {
+	if len(got) != len(want) {
+		return fmt.Errorf("%s returned %d distances, want %d", name, len(got), len(want))
+	}
+
+	for v := range want {
+		gotInf := got[v] == sssp.Infinity
+		wantInf := want[v] == sssp.Infinity
+		if gotInf != wantInf || (!wantInf && math.Abs(got[v]-want[v]) > tol) {
+			return fmt.Errorf("%s: vertex %d has distance %g, reference %g", name, v, got[v], want[v])
+		}
+	}
+	return nil
+}
+
+// naiveDijkstra is the O(V^2) textbook Dijkstra used as the reference.
+func naiveDijkstra(g *graph.Graph, source int) []float64 {
+	dist := make([]float64, g.V)
+	visited := make([]bool, g.V)
+
+	for i := range dist {
+		dist[i] = sssp.Infinity
+	}
+	dist[source] = 0
+
+	for count := 0; count < g.V; count++ {
+		minDist := sssp.Infinity
+		u := -1
+		for v := 0; v < g.V; v++ {
+			if !visited[v] && dist[v] < minDist {
+				minDist = dist[v]
+				u = v
+			}
+		}
+
+		if u == -1 {
+			break
+		}
+		visited[u] = true
+
+		for _, edge := range g.Adj[u] {
+			if dist[u]+edge.Weight < dist[edge.To] {
+				dist[edge.To] = dist[u] + edge.Weight
+			}
+		}
+	}
+
+	return dist
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/gen"
+	"github.com/phr3nzy/duan-sssp/sssp"
+)
+
+func TestCompareDistances(t *testing.T) {
+	want := []float64{0, 1.5, sssp.Infinity}
+
+	if err := compareDistances("ok", []float64{0, 1.5 + 1e-12, sssp.Infinity}, want, 1e-9); err != nil {
+		t.Errorf("within tolerance: %v", err)
+	}
+	if err := compareDistances("off", []float64{0, 1.6, sssp.Infinity}, want, 1e-9); err == nil {
+		t.Error("expected mismatch for differing distance")
+	}
+	if err := compareDistances("reach", []float64{0, 1.5, 7}, want, 1e-9); err == nil {
+		t.Error("expected mismatch for unreachable vertex")
+	}
+	if err := compareDistances("short", []float64{0}, want, 1e-9); err == nil {
+		t.Error("expected mismatch for length")
+	}
+}
+
+func TestNaiveDijkstra(t *testing.T) {
+	g := gen.Random(50, 150, 42)
+	if err := compareDistances("A*", aStarSSSP(g, 0), naiveDijkstra(g, 0), 1e-9); err != nil {
+		t.Error(err)
+	}
+}
@@ -1,12 +1,17 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net"
 	"net/http"
 	"os"
 	"os/exec"
+	"os/signal"
 	"runtime"
+	"strings"
 	"time"
 
 	"github.com/phr3nzy/duan-sssp/graph"
@@ -38,6 +43,8 @@ type Result struct {
 	Algorithm string        `json:"algorithm"`
 	Time      time.Duration `json:"time"`
 	TimeMS    float64       `json:"timeMs"`
+	StdDevMS  float64       `json:"stdDevMs"`
+	P95MS     float64       `json:"p95Ms"`
 	Speedup   float64       `json:"speedup"`
 }
 
@@ -202,67 +209,84 @@ const htmlTemplate = `<!DOCTYPE html>
         </div>
     </div>
     
+    <!-- DATA_PLACEHOLDER -->
     <script>
-        const data = DATA_PLACEHOLDER;
-        
-        // Render stats
-        const statsContainer = document.getElementById('stats');
-        const stats = [
-            { label: 'Vertices', value: data.stats.vertices.toLocaleString() },
-            { label: 'Edges', value: data.stats.edges.toLocaleString() },
-            { label: 'Avg Degree', value: data.stats.avgDegree.toFixed(2) },
-            { label: 'Max Degree', value: data.stats.maxDegree },
-            { label: 'Density', value: (data.stats.density * 100).toFixed(2) + '%' },
-            { label: 'Cores Used', value: navigator.hardwareConcurrency || '?' }
-        ];
+        const canvas = document.getElementById('graph-canvas');
+
+        // Offline snapshots embed the data; the served page fetches it
+        const embedded = document.getElementById('graph-data');
+        if (embedded) {
+            render(JSON.parse(embedded.textContent));
+        } else {
+            fetch('/api/graph').then(r => r.json()).then(render);
+        }
+
+        function render(data) {
+            // Render stats
+            const statsContainer = document.getElementById('stats');
+            const stats = [
+                { label: 'Vertices', value: data.stats.vertices.toLocaleString() },
+                { label: 'Edges', value: data.stats.edges.toLocaleString() },
+                { label: 'Avg Degree', value: data.stats.avgDegree.toFixed(2) },
+                { label: 'Max Degree', value: data.stats.maxDegree },
+                { label: 'Density', value: (data.stats.density * 100).toFixed(2) + '%' },
+                { label: 'Cores Used', value: navigator.hardwareConcurrency || '?' }
+            ];
         
-        stats.forEach(stat => {
-            const div = document.createElement('div');
-            div.className = 'stat';
-            div.innerHTML = '<div class="stat-label">' + stat.label + '</div>' +
-                          '<div class="stat-value">' + stat.value + '</div>';
-            statsContainer.appendChild(div);
-        });
+            stats.forEach(stat => {
+                const div = document.createElement('div');
+                div.className = 'stat';
+                div.innerHTML = '<div class="stat-label">' + stat.label + '</div>' +
+                              '<div class="stat-value">' + stat.value + '</div>';
+                statsContainer.appendChild(div);
+            });
         
-        // Render graph
-        const canvas = document.getElementById('graph-canvas');
-        const ctx = canvas.getContext('2d');
-        renderGraph(ctx, data.edges, data.vertices);
+            // Render graph
+            const ctx = canvas.getContext('2d');
+            renderGraph(ctx, data.edges, data.vertices);
         
-        // Render results
-        const resultsContainer = document.getElementById('results');
-        const maxTime = Math.max(...data.results.map(r => r.time));
+            // Render results
+            const resultsContainer = document.getElementById('results');
+            const maxTime = Math.max(...data.results.map(r => r.time));
         
-        data.results.forEach((result, idx) => {
-            const div = document.createElement('div');
-            div.className = 'benchmark';
+            data.results.forEach((result, idx) => {
+                const div = document.createElement('div');
+                div.className = 'benchmark';
             
-            const barWidth = (result.time / maxTime) * 100;
-            let barClass = 'fastest';
-            if (idx > 0) barClass = 'fast';
-            if (result.speedup < 0.5) barClass = 'slow';
+                const barWidth = (result.time / maxTime) * 100;
+                let barClass = 'fastest';
+                if (idx > 0) barClass = 'fast';
+                if (result.speedup < 0.5) barClass = 'slow';
             
-            div.innerHTML = 
-                '<div class="benchmark-name">' + result.algorithm + '</div>' +
-                '<div class="bar-container">' +
-                    '<div class="bar ' + barClass + '" style="width: ' + barWidth + '%">' +
-                        result.timeMs.toFixed(3) + ' ms' +
-                    '</div>' +
-                    '<span class="speedup">' + result.speedup.toFixed(2) + 'x</span>' +
-                '</div>';
+                div.innerHTML = 
+                    '<div class="benchmark-name">' + escapeHTML(result.algorithm) + '</div>' +
+                    '<div class="bar-container">' +
+                        '<div class="bar ' + barClass + '" style="width: ' + barWidth + '%">' +
+                            result.timeMs.toFixed(3) + ' ± ' + result.stdDevMs.toFixed(3) + ' ms' +
+                        '</div>' +
+                        '<span class="speedup">' + result.speedup.toFixed(2) + 'x</span>' +
+                    '</div>';
             
-            resultsContainer.appendChild(div);
-        });
+                resultsContainer.appendChild(div);
+            });
         
-        // Winner announcement
-        const winnerDiv = document.getElementById('winner');
-        const winner = data.results[0];
-        const runner = data.results[1];
-        const speedup = (runner.time / winner.time).toFixed(1);
+            // Winner announcement
+            const winnerDiv = document.getElementById('winner');
+            const winner = data.results[0];
+            const runner = data.results[1];
+            const speedup = (runner.time / winner.time).toFixed(1);
         
-        winnerDiv.className = 'winner';
-        winnerDiv.innerHTML = '🏆 ' + winner.algorithm + ' wins by ' + speedup + 'x!';
+            winnerDiv.className = 'winner';
+            winnerDiv.textContent = '🏆 ' + winner.algorithm + ' wins by ' + speedup + 'x!';
         
+        }
+
+        function escapeHTML(s) {
+            const div = document.createElement('div');
+            div.textContent = s;
+            return div.innerHTML;
+        }
+
         function renderGraph(ctx, edges, vertexCount) {
             const width = canvas.width;
             const height = canvas.height;
@@ -323,7 +347,10 @@ const htmlTemplate = `<!DOCTYPE html>
 </body>
 </html>`
 
-func startWebVisualization(g *graph.Graph, results []BenchmarkResult) {
+const vizFilename = "benchmark_viz.html"
+
+// vizData collects the sampled graph, its stats and the results for the page.
+func vizData(g *graph.Graph, results []BenchmarkResult) *GraphData {
 	// Prepare data
 	edges := make([]Edge, 0)
 	for u := 0; u < min(g.V, 100); u++ { // Limit for JSON size
@@ -338,25 +365,16 @@ func startWebVisualization(g *graph.Graph, results []BenchmarkResult) {
 		}
 	}
 
-	maxDegree := 0
-	totalDegree := 0
-	for u := 0; u < g.V; u++ {
-		deg := len(g.Adj[u])
-		totalDegree += deg
-		if deg > maxDegree {
-			maxDegree = deg
-		}
-	}
-
-	graphData := GraphData{
+	gs := g.Stats()
+	graphData := &GraphData{
 		Vertices: g.V,
 		Edges:    edges,
 		Stats: Stats{
-			Vertices:  g.V,
-			Edges:     len(edges),
-			AvgDegree: float64(totalDegree) / float64(g.V),
-			MaxDegree: maxDegree,
-			Density:   float64(totalDegree) / float64(g.V*g.V),
+			Vertices:  gs.Vertices,
+			Edges:     gs.Edges,
+			AvgDegree: gs.AvgDegree,
+			MaxDegree: gs.MaxDegree,
+			Density:   gs.Density,
 		},
 		Results: make([]Result, len(results)),
 	}
@@ -367,40 +385,76 @@ func startWebVisualization(g *graph.Graph, results []BenchmarkResult) {
 			Algorithm: r.Algorithm,
 			Time:      r.Time,
 			TimeMS:    float64(r.Time.Microseconds()) / 1000.0,
+			StdDevMS:  float64(r.Stats.StdDev.Microseconds()) / 1000.0,
+			P95MS:     float64(r.Stats.P95.Microseconds()) / 1000.0,
 			Speedup:   float64(baseline) / float64(r.Time),
 		}
 	}
 
-	jsonData, _ := json.Marshal(graphData)
+	return graphData
+}
+
+// buildVizPage renders a standalone copy of the page with data embedded, for
+// opening without the server. json.Marshal escapes <, > and &, so the data
+// cannot close the script element early.
+func buildVizPage(data *GraphData) []byte {
+	jsonData, _ := json.Marshal(data)
+	script := `<script id="graph-data" type="application/json">` + string(jsonData) + `</script>`
+	return []byte(strings.Replace(htmlTemplate, "<!-- DATA_PLACEHOLDER -->", script, 1))
+}
 
-	// Create HTML file
-	htmlContent := htmlTemplate
-	htmlContent = string([]byte(htmlContent))
-	htmlContent = replaceString(htmlContent, "DATA_PLACEHOLDER", string(jsonData))
+// newVizServer returns a server with its own mux serving the static page at
+// the root and at /benchmark_viz.html, and data as JSON at /api/graph.
+func newVizServer(data *GraphData) *http.Server {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/" && r.URL.Path != "/"+vizFilename {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		io.WriteString(w, htmlTemplate)
+	})
+	mux.HandleFunc("/api/graph", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(data)
+	})
+	return &http.Server{Handler: mux}
+}
 
-	filename := "benchmark_viz.html"
-	err := os.WriteFile(filename, []byte(htmlContent), 0644)
-	if err != nil {
-		fmt.Printf("Error creating HTML: %v\n", err)
+// startWebVisualization writes the page to disk, serves it on port (0 picks
+// a free one) and blocks until SIGINT, then shuts the server down.
+func startWebVisualization(g *graph.Graph, results []BenchmarkResult, port int) {
+	data := vizData(g, results)
+
+	if err := os.WriteFile(vizFilename, buildVizPage(data), 0644); err != nil {
+		fmt.Fprintf(out, "Error creating HTML: %v\n", err)
 		return
 	}
+	fmt.Fprintf(out, "\n%s🌐 Web visualization created: %s%s\n", colorCyan, vizFilename, colorReset)
 
-	fmt.Printf("\n%s🌐 Web visualization created: %s%s\n", colorCyan, filename, colorReset)
-	fmt.Printf("%sOpening in browser...%s\n", colorCyan, colorReset)
+	ln, err := net.Listen("tcp", fmt.Sprintf(":%d", port))
+	if err != nil {
+		fmt.Fprintf(out, "%sError starting web server: %v%s\n", colorRed, err, colorReset)
+		return
+	}
+	srv := newVizServer(data)
+	go srv.Serve(ln)
 
-	// Open in browser
-	openBrowser("http://localhost:8080/" + filename)
+	url := fmt.Sprintf("http://localhost:%d/%s", ln.Addr().(*net.TCPAddr).Port, vizFilename)
+	fmt.Fprintf(out, "%sOpening %s in browser...%s\n", colorCyan, url, colorReset)
+	openBrowser(url)
 
-	// Start simple HTTP server
-	go func() {
-		http.HandleFunc("/"+filename, func(w http.ResponseWriter, r *http.Request) {
-			w.Header().Set("Content-Type", "text/html")
-			http.ServeFile(w, r, filename)
-		})
-		http.ListenAndServe(":8080", nil)
-	}()
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	fmt.Fprintf(out, "\n%sPress Ctrl+C to exit...%s\n", colorYellow, colorReset)
+	<-ctx.Done()
 
-	time.Sleep(2 * time.Second) // Give browser time to open
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		fmt.Fprintf(out, "Error shutting down web server: %v\n", err)
+	}
 }
 
 func openBrowser(url string) {
@@ -414,37 +468,11 @@ func openBrowser(url string) {
 	case "darwin":
 		err = exec.Command("open", url).Start()
 	default:
-		fmt.Printf("Please open %s in your browser\n", url)
+		fmt.Fprintf(out, "Please open %s in your browser\n", url)
 		return
 	}
 
 	if err != nil {
-		fmt.Printf("Please open %s in your browser\n", url)
-	}
-}
-
-func replaceString(s, old, new string) string {
-	result := ""
-	remaining := s
-
-	for {
-		idx := findString(remaining, old)
-		if idx == -1 {
-			result += remaining
-			break
-		}
-		result += remaining[:idx] + new
-		remaining = remaining[idx+len(old):]
-	}
-
-	return result
-}
-
-func findString(s, substr string) int {
-	for i := 0; i <= len(s)-len(substr); i++ {
-		if s[i:i+len(substr)] == substr {
-			return i
-		}
+		fmt.Fprintf(out, "Please open %s in your browser\n", url)
 	}
-	return -1
 }
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/phr3nzy/duan-sssp/graph"
+)
+
+func testVizInput() (*graph.Graph, []BenchmarkResult) {
+	g := graph.NewGraph(3)
+	g.AddEdge(0, 1, 1.5)
+	g.AddEdge(1, 2, 2)
+	results := []BenchmarkResult{
+		{Algorithm: "Duan", Time: 2 * time.Millisecond, Vertices: 3, Edges: 2, CoreCount: 1},
+		{Algorithm: "A*", Time: time.Millisecond, Vertices: 3, Edges: 2, CoreCount: 1},
+	}
+	return g, results
+}
+
+func TestVizServerServesPage(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	srv := newVizServer(vizData(testVizInput()))
+	go srv.Serve(ln)
+	defer srv.Shutdown(context.Background())
+
+	resp, err := http.Get("http://" + ln.Addr().String() + "/" + vizFilename)
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("status %d", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/html") {
+		t.Errorf("Content-Type = %q", ct)
+	}
+	body, _ := io.ReadAll(resp.Body)
+	if string(body) != htmlTemplate {
+		t.Error("served body differs from the page template")
+	}
+}
+
+func TestVizServerRoutes(t *testing.T) {
+	h := newVizServer(vizData(testVizInput())).Handler
+	for path, want := range map[string]int{
+		"/":               http.StatusOK,
+		"/" + vizFilename: http.StatusOK,
+		"/missing":        http.StatusNotFound,
+	} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		if rec.Code != want {
+			t.Errorf("GET %s: status %d, want %d", path, rec.Code, want)
+		}
+	}
+}
+
+func TestVizGraphEndpoint(t *testing.T) {
+	want := vizData(testVizInput())
+	rec := httptest.NewRecorder()
+	newVizServer(want).Handler.ServeHTTP(rec, httptest.NewRequest("GET", "/api/graph", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status %d", rec.Code)
+	}
+	if ct := rec.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type = %q", ct)
+	}
+
+	var got GraphData
+	if err := json.Unmarshal(rec.Body.Bytes(), &got); err != nil {
+		t.Fatalf("decoding response: %v", err)
+	}
+	if !reflect.DeepEqual(&got, want) {
+		t.Errorf("round-tripped data = %+v, want %+v", got, *want)
+	}
+}
+
+// TestVizPageEscapesData embeds strings that would break naive injection and
+// checks the snapshot still holds exactly one data script that decodes intact.
+func TestVizPageEscapesData(t *testing.T) {
+	g, results := testVizInput()
+	results[0].Algorithm = "</script><script>alert(1)</script>"
+	results[1].Algorithm = "<!-- DATA_PLACEHOLDER --> DATA_PLACEHOLDER"
+	data := vizData(g, results)
+	page := string(buildVizPage(data))
+
+	if got, want := strings.Count(page, "</script>"), strings.Count(htmlTemplate, "</script>")+1; got != want {
+		t.Fatalf("page has %d </script> tags, want %d", got, want)
+	}
+	if strings.Contains(page, "<!-- DATA_PLACEHOLDER -->") {
+		t.Error("placeholder left in page")
+	}
+
+	const open = `<script id="graph-data" type="application/json">`
+	start := strings.Index(page, open)
+	if start < 0 {
+		t.Fatal("data script missing")
+	}
+	body := page[start+len(open):]
+	body = body[:strings.Index(body, "</script>")]
+
+	var got GraphData
+	if err := json.Unmarshal([]byte(body), &got); err != nil {
+		t.Fatalf("decoding embedded data: %v", err)
+	}
+	if !reflect.DeepEqual(&got, data) {
+		t.Errorf("embedded data = %+v, want %+v", got, *data)
+	}
+}
@@ -1,7 +1,10 @@
 package ds
 
 import (
+	"cmp"
+	"fmt"
 	"math"
+	"slices"
 	"sort"
 	"sync"
 )
@@ -30,6 +33,44 @@ func PutBlock(b *block) {
 	blockPool.Put(b)
 }
 
+// getItem takes a list node from the free list, allocating only when it is
+// empty.
+func (ds *DataStructure) getItem(key int, val float64) *Item {
+	item := ds.freeItems
+	if item == nil {
+		item = &Item{}
+	} else {
+		ds.freeItems = item.next
+	}
+	item.Key = key
+	item.Value = val
+	item.next = nil
+	return item
+}
+
+// putItem returns a list node to the free list.
+func (ds *DataStructure) putItem(item *Item) {
+	item.next = ds.freeItems
+	ds.freeItems = item
+}
+
+// getBlock takes an empty block from the free list, falling back to the
+// shared pool.
+func (ds *DataStructure) getBlock() *block {
+	if n := len(ds.freeBlocks); n > 0 {
+		b := ds.freeBlocks[n-1]
+		ds.freeBlocks = ds.freeBlocks[:n-1]
+		return b
+	}
+	return GetBlock()
+}
+
+// putBlock empties b and returns it to the free list.
+func (ds *DataStructure) putBlock(b *block) {
+	*b = block{}
+	ds.freeBlocks = append(ds.freeBlocks, b)
+}
+
 // Item represents a key-value pair in the frontier.
 type Item struct {
 	Key   int
@@ -52,14 +93,27 @@ type DataStructure struct {
 	B     float64 // Global upper bound
 	Count int
 
+	// Strict makes BatchPrepend panic when an item is not strictly smaller
+	// than every stored value. Meant for tests; it costs a sort of d1[0].
+	Strict bool
+
 	// D0: Sequence of blocks from BatchPrepend (unsorted between blocks, sorted within?)
 	// The paper implies D0 is a buffer. We treat D0 as a simple list of blocks
-	// where we just prepend new blocks.
+	// where we just prepend new blocks. It is stored in reverse, smallest
+	// block last, so that prepending and draining both work at the end.
 	d0 []*block
 
 	// D1: Sequence of blocks maintained in sorted order of their values.
 	// We use a slice to act as the "Search Tree" for the block headers.
 	d1 []*block
+
+	// Storage kept across Pull, Reset and splits so that a reused structure
+	// stops allocating once it has grown: Pull's result, the scratch space
+	// for splitting and sorting blocks, and free lists of nodes and blocks.
+	pulled     []Item
+	scratch    []*Item
+	freeItems  *Item
+	freeBlocks []*block
 }
 
 func NewDataStructure(m int) *DataStructure {
@@ -71,10 +125,60 @@ func NewDataStructure(m int) *DataStructure {
 	}
 }
 
+// Reset empties the structure and sets a new block size, so it can be
+// reused instead of allocating another. Items and blocks are kept for reuse;
+// B is reset to Infinity and Strict is kept.
+func (ds *DataStructure) Reset(m int) {
+	for _, list := range [2][]*block{ds.d0, ds.d1} {
+		for i, b := range list {
+			for curr := b.head; curr != nil; {
+				next := curr.next
+				ds.putItem(curr)
+				curr = next
+			}
+			ds.putBlock(b)
+			list[i] = nil
+		}
+	}
+	ds.d0 = ds.d0[:0]
+	ds.d1 = ds.d1[:0]
+	ds.Count = 0
+	ds.M = m
+	ds.B = Infinity
+}
+
+// Len returns the number of items currently stored.
+func (ds *DataStructure) Len() int {
+	return ds.Count
+}
+
+// IsEmpty reports whether no items are stored.
+func (ds *DataStructure) IsEmpty() bool {
+	return ds.Len() == 0
+}
+
+// ForEach calls fn for every stored item without modifying the structure.
+// Blocks are numbered across d0 first, then d1.
+func (ds *DataStructure) ForEach(fn func(blockIdx int, it Item)) {
+	idx := 0
+	visit := func(b *block) {
+		for curr := b.head; curr != nil; curr = curr.next {
+			fn(idx, Item{Key: curr.Key, Value: curr.Value})
+		}
+		idx++
+	}
+	for i := len(ds.d0) - 1; i >= 0; i-- {
+		visit(ds.d0[i])
+	}
+	for _, b := range ds.d1 {
+		visit(b)
+	}
+}
+
 // Insert adds a key/value pair. amortized O(max{1, log(N/M)})
 func (ds *DataStructure) Insert(key int, val float64) {
 	ds.Count++
-	item := &Item{Key: key, Value: val}
+	item := ds.getItem(key, val)
 
 	// 1. Find appropriate block in D1 via Binary Search on UpperBounds
 	// We look for the first block where upperBound >= val
@@ -86,7 +190,7 @@ func (ds *DataStructure) Insert(key int, val float64) {
 		// No block fits, or D1 is empty.
 		// If D1 is empty, create new.
 		if len(ds.d1) == 0 {
-			b := GetBlock()
+			b := ds.getBlock()
 			b.upperBound = Infinity // The last block always stretches to Infinity/B
 			b.sorted = true
 			ds.d1 = append(ds.d1, b)
@@ -121,26 +225,35 @@ func (ds *DataStructure) BatchPrepend(items []Item) {
 	if len(items) == 0 {
 		return
 	}
+	if ds.Strict {
+		if b := ds.minHeadBlock(); b != nil {
+			for _, it := range items {
+				if it.Value >= b.head.Value {
+					panic(fmt.Sprintf("ds: BatchPrepend value %v not below current minimum %v", it.Value, b.head.Value))
+				}
+			}
+		}
+	}
 	ds.Count += len(items)
 
 	// Sort items to form valid blocks
-	sort.Slice(items, func(i, j int) bool {
-		return items[i].Value < items[j].Value
+	slices.SortFunc(items, func(a, b Item) int {
+		return cmp.Compare(a.Value, b.Value)
 	})
 
-	// Chunk into blocks of size M
-	for i := 0; i < len(items); i += ds.M {
-		end := i + ds.M
-		if end > len(items) {
-			end = len(items)
-		}
+	// Chunk into blocks of size M. d0 is stored smallest block last, so the
+	// chunks are appended from the largest down.
+	last := (len(items) - 1) / ds.M * ds.M
+	for i := last; i >= 0; i -= ds.M {
+		end := min(i+ds.M, len(items))
 
 		chunk := items[i:end]
-		blk := GetBlock()
+		blk := ds.getBlock()
 		blk.sorted = true // Batch items are pre-sorted
-		// Convert chunk to linked list
-		for k := range chunk {
-			itm := &Item{Key: chunk[k].Key, Value: chunk[k].Value}
+		// Convert chunk to linked list, building from the back so the
+		// head holds the smallest value
+		for k := len(chunk) - 1; k >= 0; k-- {
+			itm := ds.getItem(chunk[k].Key, chunk[k].Value)
 			itm.next = blk.head
 			blk.head = itm
 			if blk.tail == nil {
@@ -150,127 +263,129 @@ func (ds *DataStructure) BatchPrepend(items []Item) {
 		blk.size = len(chunk)
 		blk.upperBound = chunk[len(chunk)-1].Value // Conservative UB
 
-		// Prepend to D0
-		ds.d0 = append([]*block{blk}, ds.d0...)
+		ds.d0 = append(ds.d0, blk)
 	}
 }
 
-// Pull retrieves the smallest M items.
+// Pull retrieves the smallest M items and a bound separating them from the
+// rest. Items tied with the last one pulled are pulled too, so every returned
+// value is strictly below the bound. The bound is B once the structure is
+// empty, and Pull on an empty structure returns no items and B (Infinity
+// unless set). The returned slice is reused by the next Pull.
 func (ds *DataStructure) Pull() ([]Item, float64) {
-	// This is a simplified Pull that merges D0 and D1 logically.
-	// In a full impl, you'd scan the heads of D0 and D1.
-	// Since D0 contains "small" items prepended, and D1 is sorted,
-	// we collect from D0 then D1 until we have M items.
-
-	collected := make([]Item, 0, ds.M)
-
-	// Helper to drain a block
-	drain := func(b *block, limit int) {
-		curr := b.head
-		prev := &Item{Key: 0, Value: 0, next: nil}
-		for curr != nil && len(collected) < limit {
-			collected = append(collected, *curr)
-			prev.next = curr
-			prev = curr
-			curr = curr.next
-			b.size--
-			ds.Count--
+	// D0 blocks are in ascending order (each BatchPrepend is smaller than
+	// everything present at the time) and so are D1 blocks, but a D0 block
+	// may still hold larger values than a later Insert into D1. Merge the
+	// two sequences by always taking the smaller head.
+	collected := ds.pulled[:0]
+
+	for {
+		b := ds.minHeadBlock()
+		if b == nil {
+			break
 		}
-		b.head = curr
-		if curr == nil {
-			b.tail = nil
+		if len(collected) >= ds.M && b.head.Value != collected[len(collected)-1].Value {
+			break
 		}
-	}
 
-	// 1. Drain D0 first (contains smallest from prepends)
-	// We iterate D0 backwards or forwards? BatchPrepend adds to front.
-	// Logic dictates D0 blocks are smaller than D1.
-	activeD0 := ds.d0[:0]
-	for _, blk := range ds.d0 {
-		if len(collected) < ds.M {
-			drain(blk, ds.M)
-		}
-		if blk.size > 0 {
-			activeD0 = append(activeD0, blk)
-		} else {
-			PutBlock(blk) // Return empty block to pool
-		}
-	}
-	ds.d0 = activeD0
-
-	// 2. Drain D1 if needed
-	if len(collected) < ds.M {
-		activeD1 := ds.d1[:0]
-		for _, blk := range ds.d1 {
-			if len(collected) < ds.M {
-				// Sort the block only if not already sorted
-				if !blk.sorted {
-					ds.sortBlock(blk)
-				}
-				drain(blk, ds.M)
-			}
-			if blk.size > 0 {
-				// Update UB if needed, or keep
-				activeD1 = append(activeD1, blk)
-			} else {
-				PutBlock(blk) // Return empty block to pool
-			}
+		item := b.head
+		b.head = item.next
+		if b.head == nil {
+			b.tail = nil
 		}
-		ds.d1 = activeD1
+		b.size--
+		ds.Count--
+		collected = append(collected, Item{Key: item.Key, Value: item.Value})
+		ds.putItem(item)
 	}
+	ds.pulled = collected
 
 	// Determine Bi (the bound).
-	// If we exhausted everything, Bi = Infinity.
+	// If we exhausted everything, Bi = B.
 	// Else, Bi is the value of the next available item.
-	Bi := Infinity
-	if ds.Count > 0 {
-		// Find min in remaining D0 or D1
-		// (Simplification: just peek heads. Correctness requires iterating blocks)
-		if len(ds.d0) > 0 {
-			// scan d0
-			Bi = ds.peekBlock(ds.d0[0])
-		} else if len(ds.d1) > 0 {
-			if !ds.d1[0].sorted {
-				ds.sortBlock(ds.d1[0]) // Ensure sorted to peek
-			}
-			Bi = ds.peekBlock(ds.d1[0])
-		}
+	Bi := ds.B
+	if b := ds.minHeadBlock(); b != nil {
+		Bi = b.head.Value
+	} else {
+		// Nothing is stored whatever Count says; keep callers that loop
+		// while Count > 0 from spinning on a stale value.
+		ds.Count = 0
 	}
 
 	return collected, Bi
 }
 
+// minHeadBlock returns the block whose head is the smallest stored item, or
+// nil when empty. Blocks only drain from the front, so leading empty blocks
+// are released and the first remaining block of each list holds its minimum.
+// d1 is shifted down rather than resliced so that it keeps its capacity.
+func (ds *DataStructure) minHeadBlock() *block {
+	for n := len(ds.d0); n > 0 && ds.d0[n-1].size == 0; n-- {
+		ds.putBlock(ds.d0[n-1])
+		ds.d0[n-1] = nil
+		ds.d0 = ds.d0[:n-1]
+	}
+	empty := 0
+	for empty < len(ds.d1) && ds.d1[empty].size == 0 {
+		ds.putBlock(ds.d1[empty])
+		empty++
+	}
+	if empty > 0 {
+		n := copy(ds.d1, ds.d1[empty:])
+		clear(ds.d1[n:])
+		ds.d1 = ds.d1[:n]
+	}
+
+	var best *block
+	if n := len(ds.d0); n > 0 {
+		best = ds.d0[n-1]
+	}
+	if len(ds.d1) > 0 {
+		b := ds.d1[0]
+		if !b.sorted {
+			ds.sortBlock(b)
+		}
+		if best == nil || b.head.Value < best.head.Value {
+			best = b
+		}
+	}
+	return best
+}
+
 func (ds *DataStructure) split(d1Index int) {
 	b := ds.d1[d1Index]
 
 	// Materialize list to slice for sorting/splitting
-	items := make([]*Item, 0, b.size)
-	curr := b.head
-	for curr != nil {
-		items = append(items, curr)
-		curr = curr.next
-	}
-
-	// Find median (O(M log M) with sort, or O(M) with select)
-	sort.Slice(items, func(i, j int) bool {
-		return items[i].Value < items[j].Value
-	})
+	items := ds.blockItems(b)
 
+	// Partition around the median in expected O(M); the halves stay
+	// unsorted and are sorted lazily once they reach the front
 	mid := len(items) / 2
+	selectNth(items, mid-1)
 
 	// Create new block for right half
-	newB := GetBlock()
-	newB.sorted = true
+	newB := ds.getBlock()
+	newB.sorted = false
 	newB.upperBound = b.upperBound    // Inherits old UB
-	b.upperBound = items[mid-1].Value // New UB for left block
-	b.sorted = true                   // Both halves are now sorted
+	b.upperBound = items[mid-1].Value // Max of the left half
+	b.sorted = false
 
 	// Rebuild lists
 	b.head, b.tail, b.size = listFromSlice(items[:mid])
 	newB.head, newB.tail, newB.size = listFromSlice(items[mid:])
 
 	// Insert newB into D1 after b
-	ds.d1 = append(ds.d1[:d1Index+1], append([]*block{newB}, ds.d1[d1Index+1:]...)...)
+	ds.d1 = slices.Insert(ds.d1, d1Index+1, newB)
+}
+
+// blockItems lists b's nodes in the reused scratch slice.
+func (ds *DataStructure) blockItems(b *block) []*Item {
+	items := ds.scratch[:0]
+	for curr := b.head; curr != nil; curr = curr.next {
+		items = append(items, curr)
+	}
+	ds.scratch = items
+	return items
 }
 
 func (ds *DataStructure) sortBlock(b *block) {
@@ -278,19 +393,52 @@ func (ds *DataStructure) sortBlock(b *block) {
 		b.sorted = true
 		return
 	}
-	items := make([]*Item, 0, b.size)
-	curr := b.head
-	for curr != nil {
-		items = append(items, curr)
-		curr = curr.next
-	}
-	sort.Slice(items, func(i, j int) bool {
-		return items[i].Value < items[j].Value
+	items := ds.blockItems(b)
+	slices.SortFunc(items, func(a, b *Item) int {
+		return cmp.Compare(a.Value, b.Value)
 	})
 	b.head, b.tail, b.size = listFromSlice(items)
 	b.sorted = true
 }
 
+// selectNth reorders items so that items[n] holds the value it would have
+// after sorting, with nothing larger before it and nothing smaller after.
+// It is quickselect with a median-of-three pivot and three-way partitioning,
+// so runs of equal values do not degrade it.
+func selectNth(items []*Item, n int) {
+	lo, hi := 0, len(items)-1
+	for lo < hi {
+		mid := lo + (hi-lo)/2
+		a, b, c := items[lo].Value, items[mid].Value, items[hi].Value
+		pivot := max(min(a, b), min(max(a, b), c))
+
+		// items[lo:lt] < pivot, items[lt:i] == pivot, items[gt+1:hi+1] > pivot
+		lt, i, gt := lo, lo, hi
+		for i <= gt {
+			switch v := items[i].Value; {
+			case v < pivot:
+				items[lt], items[i] = items[i], items[lt]
+				lt++
+				i++
+			case v > pivot:
+				items[i], items[gt] = items[gt], items[i]
+				gt--
+			default:
+				i++
+			}
+		}
+
+		switch {
+		case n < lt:
+			hi = lt - 1
+		case n > gt:
+			lo = gt + 1
+		default:
+			return
+		}
+	}
+}
+
 func listFromSlice(items []*Item) (*Item, *Item, int) {
 	if len(items) == 0 {
 		return nil, nil, 0
@@ -305,9 +453,48 @@ func listFromSlice(items []*Item) (*Item, *Item, int) {
 	return head, curr, len(items)
 }
 
-func (ds *DataStructure) peekBlock(b *block) float64 {
-	if b.head == nil {
-		return Infinity
+// checkInvariants verifies the structure's bookkeeping: block sizes and
+// tails match their lists, sorted blocks are sorted, no block holds more
+// than M items or a value above its upper bound, d1 bounds are
+// non-decreasing with every value at least the previous block's bound, and
+// Count is the total number of items.
+func (ds *DataStructure) checkInvariants() error {
+	total := 0
+	for name, list := range map[string][]*block{"d0": ds.d0, "d1": ds.d1} {
+		for i, b := range list {
+			n := 0
+			var last *Item
+			for curr := b.head; curr != nil; curr = curr.next {
+				if curr.Value > b.upperBound {
+					return fmt.Errorf("ds: %s block %d: value %v above upper bound %v", name, i, curr.Value, b.upperBound)
+				}
+				if b.sorted && last != nil && curr.Value < last.Value {
+					return fmt.Errorf("ds: %s block %d: marked sorted but %v follows %v", name, i, curr.Value, last.Value)
+				}
+				if name == "d1" && i > 0 && curr.Value < list[i-1].upperBound {
+					return fmt.Errorf("ds: d1 block %d: value %v below previous bound %v", i, curr.Value, list[i-1].upperBound)
+				}
+				last = curr
+				n++
+			}
+			if n != b.size {
+				return fmt.Errorf("ds: %s block %d: size %d, list has %d items", name, i, b.size, n)
+			}
+			if b.tail != last {
+				return fmt.Errorf("ds: %s block %d: tail does not point at the last item", name, i)
+			}
+			if n > ds.M {
+				return fmt.Errorf("ds: %s block %d: %d items exceed M = %d", name, i, n, ds.M)
+			}
+			if name == "d1" && i > 0 && b.upperBound < list[i-1].upperBound {
+				return fmt.Errorf("ds: d1 block %d: upper bound %v below previous %v", i, b.upperBound, list[i-1].upperBound)
+			}
+			total += n
+		}
+	}
+
+	if total != ds.Count {
+		return fmt.Errorf("ds: Count = %d, blocks hold %d items", ds.Count, total)
 	}
-	return b.head.Value
+	return nil
 }
@@ -0,0 +1,465 @@
+package ds
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestLen(t *testing.T) {
+	d := NewDataStructure(2)
+	if !d.IsEmpty() || d.Len() != 0 {
+		t.Fatalf("new structure: Len = %d, IsEmpty = %v", d.Len(), d.IsEmpty())
+	}
+
+	d.Insert(0, 5)
+	d.Insert(1, 7)
+	d.Insert(2, 6)
+	if d.Len() != 3 {
+		t.Errorf("after 3 inserts: Len = %d", d.Len())
+	}
+
+	d.BatchPrepend([]Item{{Key: 3, Value: 1}, {Key: 4, Value: 2}})
+	if d.Len() != 5 {
+		t.Errorf("after batch prepend: Len = %d", d.Len())
+	}
+
+	pulled, _ := d.Pull()
+	if d.Len() != 5-len(pulled) {
+		t.Errorf("after pulling %d: Len = %d", len(pulled), d.Len())
+	}
+
+	for !d.IsEmpty() {
+		d.Pull()
+	}
+	if d.Len() != 0 {
+		t.Errorf("drained structure: Len = %d", d.Len())
+	}
+}
+
+func TestPullEmpty(t *testing.T) {
+	check := func(name string, d *DataStructure, wantBound float64) {
+		t.Helper()
+		items, bound := d.Pull()
+		if len(items) != 0 || bound != wantBound || d.Count != 0 {
+			t.Errorf("%s: Pull = %v, %v with Count %d; want no items, %v", name, items, bound, d.Count, wantBound)
+		}
+	}
+
+	check("fresh", NewDataStructure(4), Infinity)
+
+	d := NewDataStructure(2)
+	d.B = 100
+	for k := 0; k < 7; k++ {
+		d.Insert(k, float64(k))
+	}
+	d.BatchPrepend([]Item{{Key: 7, Value: -1}})
+	for d.Count > 0 {
+		d.Pull()
+	}
+	check("drained", d, 100)
+	check("drained twice", d, 100)
+
+	stale := NewDataStructure(4)
+	stale.Count = 3
+	check("stale count", stale, Infinity)
+}
+
+func TestSplit(t *testing.T) {
+	for _, values := range [][]float64{
+		{9, 3, 7, 1, 8, 2, 6, 4, 5},
+		{5, 5, 5, 1, 5, 5, 9, 5, 5},
+		{1, 2, 3, 4, 5, 6, 7, 8, 9},
+		{9, 8, 7, 6, 5, 4, 3, 2, 1},
+	} {
+		d := NewDataStructure(len(values) - 1)
+		for k, v := range values {
+			d.Insert(k, v) // the last insert overflows the block and splits it
+		}
+		if len(d.d1) != 2 {
+			t.Fatalf("%v: %d blocks after split, want 2", values, len(d.d1))
+		}
+
+		left, right := d.d1[0], d.d1[1]
+		if left.size != len(values)/2 || right.size != len(values)-len(values)/2 {
+			t.Errorf("%v: sizes %d and %d", values, left.size, right.size)
+		}
+		sorted := slices.Clone(values)
+		slices.Sort(sorted)
+		if left.upperBound != sorted[left.size-1] || right.upperBound != Infinity {
+			t.Errorf("%v: upper bounds %v and %v, want %v and Infinity", values, left.upperBound, right.upperBound, sorted[left.size-1])
+		}
+		if err := d.CheckInvariants(); err != nil {
+			t.Errorf("%v: %v", values, err)
+		}
+	}
+}
+
+func TestSelectNth(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for trial := 0; trial < 200; trial++ {
+		n := 1 + rng.Intn(100)
+		items := make([]*Item, n)
+		values := make([]float64, n)
+		for i := range items {
+			values[i] = float64(rng.Intn(10 + trial))
+			items[i] = &Item{Key: i, Value: values[i]}
+		}
+		slices.Sort(values)
+
+		k := rng.Intn(n)
+		selectNth(items, k)
+		if items[k].Value != values[k] {
+			t.Fatalf("trial %d: items[%d] = %v, want %v", trial, k, items[k].Value, values[k])
+		}
+		for i, it := range items {
+			if (i < k && it.Value > values[k]) || (i > k && it.Value < values[k]) {
+				t.Fatalf("trial %d: items[%d] = %v on the wrong side of %v", trial, i, it.Value, values[k])
+			}
+		}
+	}
+}
+
+func TestReset(t *testing.T) {
+	d := NewDataStructure(2)
+	d.B = 50
+	for k := 0; k < 10; k++ {
+		d.Insert(k, float64(10+k))
+	}
+	d.BatchPrepend([]Item{{Key: 10, Value: 1}, {Key: 11, Value: 2}})
+
+	d.Reset(4)
+	if !d.IsEmpty() || d.M != 4 || d.B != Infinity {
+		t.Fatalf("after Reset: Len = %d, M = %d, B = %v", d.Len(), d.M, d.B)
+	}
+	if err := d.CheckInvariants(); err != nil {
+		t.Fatal(err)
+	}
+
+	d.Insert(1, 3)
+	d.Insert(2, 1)
+	items, bound := d.Pull()
+	if len(items) != 2 || items[0].Key != 2 || items[1].Key != 1 || bound != Infinity {
+		t.Errorf("Pull after Reset = %v, %v", items, bound)
+	}
+}
+
+func TestForEach(t *testing.T) {
+	d := NewDataStructure(2)
+	want := make(map[int]float64)
+	for k, v := range []float64{10, 14, 12, 11, 13} {
+		d.Insert(k, v)
+		want[k] = v
+	}
+	d.BatchPrepend([]Item{{Key: 5, Value: 3}, {Key: 6, Value: 4}, {Key: 7, Value: 5}})
+	want[5], want[6], want[7] = 3, 4, 5
+
+	pulled, _ := d.Pull()
+	for _, it := range pulled {
+		delete(want, it.Key)
+	}
+
+	// Walk twice to check ForEach leaves the structure intact
+	for pass := 0; pass < 2; pass++ {
+		got := make(map[int]float64)
+		d.ForEach(func(_ int, it Item) {
+			got[it.Key] = it.Value
+		})
+		if len(got) != len(want) {
+			t.Fatalf("pass %d: ForEach visited %d items, want %d", pass, len(got), len(want))
+		}
+		for k, v := range want {
+			if got[k] != v {
+				t.Errorf("pass %d: key %d = %v, want %v", pass, k, got[k], v)
+			}
+		}
+	}
+}
+
+// TestPullMergesD0AndD1 covers a BatchPrepend block in d0 holding larger
+// values than a later Insert into d1; Pull must still return the smallest.
+func TestPullMergesD0AndD1(t *testing.T) {
+	d := NewDataStructure(2)
+	d.BatchPrepend([]Item{{Key: 0, Value: 5}, {Key: 1, Value: 6}})
+	d.Insert(2, 1)
+	d.Insert(3, 7)
+
+	pulled, bound := d.Pull()
+	if len(pulled) != 2 || pulled[0].Value != 1 || pulled[1].Value != 5 {
+		t.Fatalf("pulled %v, want values [1 5]", pulled)
+	}
+	if bound != 6 {
+		t.Errorf("bound = %v, want 6", bound)
+	}
+
+	pulled, bound = d.Pull()
+	if len(pulled) != 2 || pulled[0].Value != 6 || pulled[1].Value != 7 {
+		t.Fatalf("pulled %v, want values [6 7]", pulled)
+	}
+	if bound != d.B {
+		t.Errorf("bound on empty structure = %v, want B", bound)
+	}
+}
+
+// TestStrictBatchPrepend checks that Strict mode rejects a prepended value
+// that is not below the current minimum.
+func TestStrictBatchPrepend(t *testing.T) {
+	d := NewDataStructure(4)
+	d.Strict = true
+	d.Insert(0, 3)
+	d.BatchPrepend([]Item{{Key: 1, Value: 1}, {Key: 2, Value: 2}})
+
+	defer func() {
+		if recover() == nil {
+			t.Error("BatchPrepend of a value equal to the minimum did not panic")
+		}
+	}()
+	d.BatchPrepend([]Item{{Key: 3, Value: 0}, {Key: 4, Value: 1}})
+}
+
+// FuzzDataStructure applies a random sequence of Insert/BatchPrepend/Pull
+// operations and checks the ordering and Count invariants. Operations respect
+// the preconditions BMSSP relies on: inserts are never below the last pulled
+// value, and batch prepends are below everything currently stored.
+func FuzzDataStructure(f *testing.F) {
+	f.Add([]byte{2, 0, 5, 0, 3, 1, 2, 2, 0, 9, 2})
+	f.Add([]byte{1, 1, 4, 0, 0, 0, 0, 2, 1, 3, 2, 2})
+	f.Add([]byte{4, 0, 1, 0, 1, 0, 1, 0, 1, 0, 1, 2, 1, 5, 2, 2, 2})
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		if len(data) == 0 {
+			return
+		}
+		m := 1 + int(data[0]%8)
+		data = data[1:]
+
+		d := NewDataStructure(m)
+		d.Strict = true
+		var stored []float64 // model of every value currently held
+		floor := 0.0         // largest value pulled so far
+		key := 0
+
+		minStored := func() float64 {
+			min := Infinity
+			for _, v := range stored {
+				if v < min {
+					min = v
+				}
+			}
+			return min
+		}
+
+		for len(data) > 0 {
+			op := data[0] % 3
+			data = data[1:]
+
+			switch op {
+			case 0: // Insert
+				if len(data) == 0 {
+					return
+				}
+				v := floor + float64(data[0]%16)
+				data = data[1:]
+				d.Insert(key, v)
+				key++
+				stored = append(stored, v)
+
+			case 1: // BatchPrepend
+				if len(data) == 0 {
+					return
+				}
+				n := 1 + int(data[0]%8)
+				data = data[1:]
+				hi := minStored()
+				if hi == Infinity {
+					hi = floor + 16
+				}
+				if hi <= floor {
+					continue
+				}
+				items := make([]Item, 0, n)
+				for i := 0; i < n; i++ {
+					v := floor + (hi-floor)*float64(i)/float64(n)
+					if v >= hi {
+						continue // rounding when hi is within a few ulps of floor
+					}
+					items = append(items, Item{Key: key, Value: v})
+					key++
+					stored = append(stored, v)
+				}
+				d.BatchPrepend(items)
+
+			case 2: // Pull
+				pulled, bound := d.Pull()
+				if len(stored) > 0 && len(pulled) == 0 {
+					t.Fatalf("Pull returned nothing with %d items stored", len(stored))
+				}
+				if len(pulled) < m && len(pulled) < len(stored) {
+					t.Fatalf("Pull returned %d items, want at least %d", len(pulled), m)
+				}
+
+				prev := floor
+				for _, it := range pulled {
+					if it.Value < prev {
+						t.Fatalf("pulled %v after %v", it.Value, prev)
+					}
+					if it.Value >= bound {
+						t.Fatalf("pulled %v not below bound %v", it.Value, bound)
+					}
+					prev = it.Value
+				}
+				floor = prev
+
+				sort.Float64s(stored)
+				stored = stored[len(pulled):]
+				for _, it := range pulled {
+					if len(stored) > 0 && it.Value > stored[0] {
+						t.Fatalf("pulled %v while %v is still stored", it.Value, stored[0])
+					}
+				}
+				if len(stored) > 0 && bound != stored[0] {
+					t.Fatalf("bound %v, want smallest remaining %v", bound, stored[0])
+				}
+				if len(stored) == 0 && bound != d.B {
+					t.Fatalf("bound %v on empty structure, want B=%v", bound, d.B)
+				}
+			}
+
+			if d.Count != len(stored) {
+				t.Fatalf("Count = %d, stored %d", d.Count, len(stored))
+			}
+			if err := d.CheckInvariants(); err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+}
+
+// TestInvariantsRandomOps checks the structure's invariants after every step
+// of a long random mix of Insert, BatchPrepend and Pull.
+func TestInvariantsRandomOps(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		d := NewDataStructure(1 + rng.Intn(16))
+		floor := 0.0 // no value below this has been pulled yet
+		key := 0
+
+		for step := 0; step < 2000; step++ {
+			switch r := rng.Intn(10); {
+			case r < 6:
+				d.Insert(key, floor+rng.Float64()*100)
+				key++
+			case r < 8:
+				hi := floor + 1
+				if b := d.minHeadBlock(); b != nil {
+					hi = b.head.Value
+				}
+				items := make([]Item, 1+rng.Intn(20))
+				for i := range items {
+					items[i] = Item{Key: key, Value: floor + (hi-floor)*rng.Float64()*0.999}
+					key++
+				}
+				d.BatchPrepend(items)
+			default:
+				pulled, _ := d.Pull()
+				for _, it := range pulled {
+					floor = max(floor, it.Value)
+				}
+			}
+
+			if err := d.CheckInvariants(); err != nil {
+				t.Fatalf("seed %d step %d: %v", seed, step, err)
+			}
+		}
+	}
+}
+
+func TestCheckInvariantsDetectsCorruption(t *testing.T) {
+	d := NewDataStructure(4)
+	for i := 0; i < 10; i++ {
+		d.Insert(i, float64(i))
+	}
+	if err := d.CheckInvariants(); err != nil {
+		t.Fatalf("valid structure rejected: %v", err)
+	}
+
+	d.Count++
+	if d.CheckInvariants() == nil {
+		t.Error("wrong Count not detected")
+	}
+	d.Count--
+
+	d.d1[0].size++
+	if d.CheckInvariants() == nil {
+		t.Error("wrong block size not detected")
+	}
+}
+
+// BenchmarkInsertPull measures allocations of a steady Insert/Pull cycle.
+func BenchmarkInsertPull(b *testing.B) {
+	d := NewDataStructure(64)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for k := 0; k < 256; k++ {
+			d.Insert(k, float64(i+(k*7919)%256))
+		}
+		for d.Count > 0 {
+			d.Pull()
+		}
+	}
+}
+
+// BenchmarkReuse compares allocating a structure per use with Reset.
+func BenchmarkReuse(b *testing.B) {
+	fill := func(d *DataStructure) {
+		for k := 0; k < 64; k++ {
+			d.Insert(k, float64((k*7919)%64))
+		}
+		d.Pull()
+	}
+
+	b.Run("New", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			fill(NewDataStructure(16))
+		}
+	})
+	b.Run("Reset", func(b *testing.B) {
+		d := NewDataStructure(16)
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			d.Reset(16)
+			fill(d)
+		}
+	})
+}
+
+// BenchmarkSplit splits one full block of M+1 random values.
+func BenchmarkSplit(b *testing.B) {
+	for _, m := range []int{64, 1024, 16384} {
+		b.Run(fmt.Sprintf("M=%d", m), func(b *testing.B) {
+			rng := rand.New(rand.NewSource(1))
+			values := make([]float64, m+1)
+			for i := range values {
+				values[i] = rng.Float64()
+			}
+
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				d := NewDataStructure(m + 1)
+				for k, v := range values {
+					d.Insert(k, v)
+				}
+				d.M = m
+				b.StartTimer()
+
+				d.split(0)
+			}
+		})
+	}
+}
@@ -0,0 +1,6 @@
+package ds
+
+// CheckInvariants exposes checkInvariants to tests.
+func (ds *DataStructure) CheckInvariants() error {
+	return ds.checkInvariants()
+}
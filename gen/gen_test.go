@@ -0,0 +1,37 @@
+package gen
+
+import (
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/graph"
+)
+
+func TestRandomDeterministic(t *testing.T) {
+	a := Random(500, 2000, 7)
+	b := Random(500, 2000, 7)
+	if !graph.Equal(a, b) {
+		t.Fatalf("same seed produced different graphs: %v", graph.Diff(a, b))
+	}
+	if graph.Equal(a, Random(500, 2000, 8)) {
+		t.Error("different seeds produced the same graph")
+	}
+	if a.NumEdges() != 2000 {
+		t.Errorf("NumEdges = %d, want 2000", a.NumEdges())
+	}
+}
+
+func TestRandomNoSelfLoops(t *testing.T) {
+	for _, n := range []int{0, 1, 2, 3, 50} {
+		g := Random(n, 10*n+5, int64(n))
+		for u := range g.Adj {
+			for _, e := range g.Adj[u] {
+				if e.To == u {
+					t.Fatalf("n=%d: self-loop at %d", n, u)
+				}
+				if e.Weight < 1 || e.Weight >= 101 {
+					t.Fatalf("n=%d: weight %v outside [1, 101)", n, e.Weight)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package graph
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// LoadEdgeListCSV reads "u,v,weight" rows, optionally after a header row.
+// The vertex count is one more than the largest index seen. Blank lines are
+// skipped; errors name the 1-based line of the offending row.
+func LoadEdgeListCSV(r io.Reader, hasHeader bool) (*Graph, error) {
+	var edges []EdgeTriple
+	maxID := -1
+
+	err := readEdgeCSV(r, hasHeader, func(line int, e EdgeTriple) error {
+		edges = append(edges, e)
+		maxID = max(maxID, e.From, e.To)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	g := NewGraph(maxID + 1)
+	for _, e := range edges {
+		g.AddEdge(e.From, e.To, e.Weight)
+	}
+	return g, nil
+}
+
+// readEdgeCSV parses "u,v,weight" rows from r and calls fn for each, with
+// the row's 1-based line, stopping at the first error from either.
+func readEdgeCSV(r io.Reader, hasHeader bool, fn func(line int, e EdgeTriple) error) error {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.TrimLeadingSpace = true
+	cr.ReuseRecord = true
+
+	for first := true; ; first = false {
+		rec, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("graph: reading CSV: %w", err)
+		}
+		line, _ := cr.FieldPos(0)
+		if first && hasHeader {
+			continue
+		}
+		if len(rec) != 3 {
+			return fmt.Errorf("graph: line %d: want 3 columns, got %d", line, len(rec))
+		}
+
+		u, err := strconv.Atoi(strings.TrimSpace(rec[0]))
+		if err != nil || u < 0 {
+			return fmt.Errorf("graph: line %d: invalid source %q", line, rec[0])
+		}
+		v, err := strconv.Atoi(strings.TrimSpace(rec[1]))
+		if err != nil || v < 0 {
+			return fmt.Errorf("graph: line %d: invalid target %q", line, rec[1])
+		}
+		w, err := strconv.ParseFloat(strings.TrimSpace(rec[2]), 64)
+		if err != nil {
+			return fmt.Errorf("graph: line %d: invalid weight %q", line, rec[2])
+		}
+
+		if err := fn(line, EdgeTriple{From: u, To: v, Weight: w}); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package graph
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadEdgeListCSV(t *testing.T) {
+	in := "u,v,weight\n3,0,2.5\n0,1,1\n1,3, 4\n\n\n"
+	g, err := LoadEdgeListCSV(strings.NewReader(in), true)
+	if err != nil {
+		t.Fatalf("LoadEdgeListCSV: %v", err)
+	}
+	if g.V != 4 {
+		t.Fatalf("V = %d, want 4", g.V)
+	}
+
+	want := map[int][]Edge{
+		0: {{To: 1, Weight: 1}},
+		1: {{To: 3, Weight: 4}},
+		3: {{To: 0, Weight: 2.5}},
+	}
+	for u := 0; u < g.V; u++ {
+		if len(g.Adj[u]) != len(want[u]) {
+			t.Fatalf("vertex %d: %d edges, want %d", u, len(g.Adj[u]), len(want[u]))
+		}
+		for i, e := range want[u] {
+			if g.Adj[u][i] != e {
+				t.Errorf("vertex %d edge %d = %+v, want %+v", u, i, g.Adj[u][i], e)
+			}
+		}
+	}
+}
+
+func TestLoadEdgeListCSVNoHeader(t *testing.T) {
+	g, err := LoadEdgeListCSV(strings.NewReader("0,1,1\n1,2,1\n"), false)
+	if err != nil {
+		t.Fatalf("LoadEdgeListCSV: %v", err)
+	}
+	if g.V != 3 {
+		t.Errorf("V = %d, want 3", g.V)
+	}
+}
+
+func TestLoadEdgeListCSVErrors(t *testing.T) {
+	cases := map[string]string{
+		"non-numeric":  "0,1,1\nx,2,1\n",
+		"bad weight":   "0,1,1\n1,2,abc\n",
+		"column count": "0,1,1\n1,2\n",
+	}
+	for name, in := range cases {
+		_, err := LoadEdgeListCSV(strings.NewReader(in), false)
+		if err == nil {
+			t.Errorf("%s: expected error", name)
+			continue
+		}
+		if !strings.Contains(err.Error(), "line 2") {
+			t.Errorf("%s: error %q does not name line 2", name, err)
+		}
+	}
+}
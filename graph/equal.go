@@ -0,0 +1,75 @@
+package graph
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// Equal reports whether a and b have the same vertex count and the same
+// multiset of edges out of every vertex, ignoring insertion order.
+func Equal(a, b *Graph) bool {
+	return len(Diff(a, b)) == 0
+}
+
+// Diff describes how b differs from a, one line per discrepancy. Edge order
+// within an adjacency list is ignored. It returns nil for equal graphs.
+func Diff(a, b *Graph) []string {
+	if a.V != b.V {
+		return []string{fmt.Sprintf("vertex count: %d in a, %d in b", a.V, b.V)}
+	}
+
+	var diffs []string
+	for u := 0; u < a.V; u++ {
+		onlyA, onlyB := unmatchedEdges(a.Adj[u], b.Adj[u])
+
+		// Pair edges to the same target so a changed weight reads as one line
+		for len(onlyA) > 0 {
+			e := onlyA[0]
+			onlyA = onlyA[1:]
+			if i := slices.IndexFunc(onlyB, func(f Edge) bool { return f.To == e.To }); i >= 0 {
+				diffs = append(diffs, fmt.Sprintf("vertex %d: edge %d->%d weight %v in a, %v in b", u, u, e.To, e.Weight, onlyB[i].Weight))
+				onlyB = slices.Delete(onlyB, i, i+1)
+				continue
+			}
+			diffs = append(diffs, fmt.Sprintf("vertex %d: edge %d->%d (weight %v) only in a", u, u, e.To, e.Weight))
+		}
+		for _, e := range onlyB {
+			diffs = append(diffs, fmt.Sprintf("vertex %d: edge %d->%d (weight %v) only in b", u, u, e.To, e.Weight))
+		}
+	}
+	return diffs
+}
+
+// unmatchedEdges returns the edges of a not matched in b and vice versa.
+func unmatchedEdges(a, b []Edge) ([]Edge, []Edge) {
+	byTarget := func(x, y Edge) int {
+		if c := cmp.Compare(x.To, y.To); c != 0 {
+			return c
+		}
+		return cmp.Compare(x.Weight, y.Weight)
+	}
+	a = slices.Clone(a)
+	b = slices.Clone(b)
+	slices.SortFunc(a, byTarget)
+	slices.SortFunc(b, byTarget)
+
+	var onlyA, onlyB []Edge
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		switch c := byTarget(a[i], b[j]); {
+		case c == 0:
+			i++
+			j++
+		case c < 0:
+			onlyA = append(onlyA, a[i])
+			i++
+		default:
+			onlyB = append(onlyB, b[j])
+			j++
+		}
+	}
+	onlyA = append(onlyA, a[i:]...)
+	onlyB = append(onlyB, b[j:]...)
+	return onlyA, onlyB
+}
@@ -0,0 +1,63 @@
+package graph
+
+import (
+	"slices"
+	"testing"
+)
+
+func sampleGraph() *Graph {
+	g := NewGraph(3)
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(0, 2, 4)
+	g.AddEdge(1, 2, 2)
+	g.AddEdge(1, 2, 2)
+	return g
+}
+
+func TestEqual(t *testing.T) {
+	if !Equal(sampleGraph(), sampleGraph()) {
+		t.Error("identical graphs not equal")
+	}
+
+	reordered := NewGraph(3)
+	reordered.AddEdge(1, 2, 2)
+	reordered.AddEdge(0, 2, 4)
+	reordered.AddEdge(1, 2, 2)
+	reordered.AddEdge(0, 1, 1)
+	if !Equal(sampleGraph(), reordered) {
+		t.Errorf("reordered graphs not equal: %v", Diff(sampleGraph(), reordered))
+	}
+
+	// Duplicate edges are counted, not collapsed
+	fewer := NewGraph(3)
+	fewer.AddEdge(0, 1, 1)
+	fewer.AddEdge(0, 2, 4)
+	fewer.AddEdge(1, 2, 2)
+	if Equal(sampleGraph(), fewer) {
+		t.Error("graphs with different edge multiplicity reported equal")
+	}
+}
+
+func TestDiff(t *testing.T) {
+	changed := sampleGraph()
+	changed.Adj[0][1].Weight = 5
+
+	want := []string{"vertex 0: edge 0->2 weight 4 in a, 5 in b"}
+	if got := Diff(sampleGraph(), changed); !slices.Equal(got, want) {
+		t.Errorf("Diff = %q, want %q", got, want)
+	}
+
+	extra := sampleGraph()
+	extra.AddEdge(2, 0, 3)
+	want = []string{"vertex 2: edge 2->0 (weight 3) only in b"}
+	if got := Diff(sampleGraph(), extra); !slices.Equal(got, want) {
+		t.Errorf("Diff = %q, want %q", got, want)
+	}
+
+	if got := Diff(sampleGraph(), NewGraph(4)); len(got) != 1 {
+		t.Errorf("vertex count mismatch: Diff = %q", got)
+	}
+	if got := Diff(sampleGraph(), sampleGraph()); got != nil {
+		t.Errorf("equal graphs: Diff = %q", got)
+	}
+}
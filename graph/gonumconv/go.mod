@@ -0,0 +1,12 @@
+module github.com/phr3nzy/duan-sssp/graph/gonumconv
+
+go 1.21
+
+require (
+	github.com/phr3nzy/duan-sssp v0.0.0
+	gonum.org/v1/gonum v0.14.0
+)
+
+require golang.org/x/exp v0.0.0-20230321023759-10a507213a29 // indirect
+
+replace github.com/phr3nzy/duan-sssp => ../..
@@ -0,0 +1,44 @@
+// Package gonumconv converts gonum graphs to this module's graph.Graph. It is
+// a separate module so the core packages do not depend on gonum.
+package gonumconv
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/phr3nzy/duan-sssp/graph"
+	gonum "gonum.org/v1/gonum/graph"
+)
+
+// FromGonum copies the nodes and weighted edges of g into a new Graph. Node
+// IDs are assigned in increasing order of gonum ID; the returned map gives
+// the new ID of each gonum node. An error is returned for a negative or NaN
+// edge weight.
+func FromGonum(g gonum.WeightedDirected) (*graph.Graph, map[int64]int, error) {
+	var ids []int64
+	nodes := g.Nodes()
+	for nodes.Next() {
+		ids = append(ids, nodes.Node().ID())
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	index := make(map[int64]int, len(ids))
+	for i, id := range ids {
+		index[id] = i
+	}
+
+	out := graph.NewGraph(len(ids))
+	for u, uid := range ids {
+		to := g.From(uid)
+		for to.Next() {
+			vid := to.Node().ID()
+			w := g.WeightedEdge(uid, vid).Weight()
+			if w < 0 || math.IsNaN(w) {
+				return nil, nil, fmt.Errorf("gonumconv: edge %d->%d has weight %v", uid, vid, w)
+			}
+			out.AddEdge(u, index[vid], w)
+		}
+	}
+	return out, index, nil
+}
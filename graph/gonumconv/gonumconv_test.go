@@ -0,0 +1,55 @@
+package gonumconv
+
+import (
+	"math"
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/sssp"
+	"gonum.org/v1/gonum/graph/path"
+	"gonum.org/v1/gonum/graph/simple"
+)
+
+func TestFromGonumDistances(t *testing.T) {
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	edges := []struct {
+		u, v int64
+		w    float64
+	}{
+		{10, 20, 4}, {10, 30, 1}, {30, 20, 2}, {20, 40, 5}, {30, 40, 8}, {40, 10, 3},
+	}
+	for _, e := range edges {
+		g.SetWeightedEdge(g.NewWeightedEdge(simple.Node(e.u), simple.Node(e.v), e.w))
+	}
+	g.AddNode(simple.Node(50)) // isolated
+
+	out, index, err := FromGonum(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.V != 5 || len(index) != 5 {
+		t.Fatalf("V = %d, %d mapped ids, want 5", out.V, len(index))
+	}
+
+	for _, src := range []int64{10, 30, 50} {
+		want := path.DijkstraFrom(simple.Node(src), g)
+		tg := out.ToConstantDegree()
+		dist := tg.MapDistances(sssp.NewSolver(tg.G).Run(tg.OriginalTo[index[src]]))
+		for id, v := range index {
+			w := want.WeightTo(id)
+			if math.IsInf(w, 1) {
+				w = sssp.Infinity
+			}
+			if dist[v] != w {
+				t.Errorf("from %d: dist to %d = %v, want %v", src, id, dist[v], w)
+			}
+		}
+	}
+}
+
+func TestFromGonumRejectsNegativeWeight(t *testing.T) {
+	g := simple.NewWeightedDirectedGraph(0, math.Inf(1))
+	g.SetWeightedEdge(g.NewWeightedEdge(simple.Node(0), simple.Node(1), -1))
+	if _, _, err := FromGonum(g); err == nil {
+		t.Error("negative weight accepted")
+	}
+}
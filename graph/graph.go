@@ -1,5 +1,15 @@
 package graph
 
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// MaxTransformedVertices bounds the vertex count ToConstantDegreeE accepts,
+// so transformed IDs fit in an int on 32-bit builds as well.
+const MaxTransformedVertices = math.MaxInt32
+
 // Edge represents a weighted directed connection.
 type Edge struct {
 	To     int
@@ -23,115 +33,168 @@ func (g *Graph) AddEdge(u, v int, w float64) {
 	g.Adj[u] = append(g.Adj[u], Edge{To: v, Weight: w})
 }
 
-// TransformedGraph holds the new graph and mapping data.
-type TransformedGraph struct {
-	G           *Graph
-	OriginalTo  []int // Map original ID -> Start node in cycle
-	NewToOrigin []int // Map new ID -> Original ID
+// OutDegree returns the number of edges leaving v.
+func (g *Graph) OutDegree(v int) int {
+	return len(g.Adj[v])
 }
 
-// ToConstantDegree implements the transformation described in the paper.
-// Each vertex v is replaced by a cycle of nodes, one for each edge.
-func (g *Graph) ToConstantDegree() *TransformedGraph {
-	// 1. Calculate size of new graph
-	// Each original vertex v needs degree(v) + constant auxiliary nodes.
-	// Simple strategy:
-	// For vertex v with In-degree I and Out-degree O:
-	// Create a "hub" cycle of size max(1, I+O).
-
-	// Faster Approach:
-	// Simply split node u into u_in and u_out is not enough for constant degree.
-	// We must chain edges.
-
-	// Implementation of [Fre83] style transformation:
-	// For each node u, create a chain/cycle of auxiliary nodes.
-	// Total new nodes approx = 2*|E| + |V|.
-
-	// We need to group edges.
-	// Construct simplified expansion:
-	// For each node u:
-	//   Create a "chain" of nodes u_0, u_1, ... u_k where k = out_degree.
-	//   Edge (u,v) becomes (u_i, v_0) with weight w.
-	//   Chain edges (u_i, u_{i+1}) have weight 0.
-	//   Incoming edges?
-	//   The paper says: "Substitute each vertex v with a cycle... For every neighbor w there is a vertex x_vw".
-
-	// Let's use a simpler gadget:
-	// Every original node u becomes a cycle of k nodes, where k = InDegree(u) + OutDegree(u).
-	// If k=0, just 1 node.
+// InDegree returns the number of edges entering v. It scans every edge, so
+// it costs O(V+E); use InDegrees when more than one vertex is needed.
+func (g *Graph) InDegree(v int) int {
+	n := 0
+	for _, adj := range g.Adj {
+		for _, e := range adj {
+			if e.To == v {
+				n++
+			}
+		}
+	}
+	return n
+}
 
+// InDegrees returns the in-degree of every vertex in one O(V+E) pass. The
+// result is not cached, since Adj may be modified directly.
+func (g *Graph) InDegrees() []int {
 	inDegree := make([]int, g.V)
-	for u := 0; u < g.V; u++ {
-		for _, e := range g.Adj[u] {
+	for _, adj := range g.Adj {
+		for _, e := range adj {
 			inDegree[e.To]++
 		}
 	}
+	return inDegree
+}
 
-	starts := make([]int, g.V)
-	sizes := make([]int, g.V)
+// NumEdges returns the number of directed edges. It is not cached, since
+// Adj may be modified directly, and costs O(V).
+func (g *Graph) NumEdges() int {
+	m := 0
+	for _, adj := range g.Adj {
+		m += len(adj)
+	}
+	return m
+}
 
-	currentID := 0
-	for u := 0; u < g.V; u++ {
-		starts[u] = currentID
-		sz := len(g.Adj[u]) + inDegree[u]
-		if sz == 0 {
-			sz = 1
+// Reverse returns a new graph with every edge of g flipped, so that u->v of
+// weight w becomes v->u of weight w. Edges are added in g's adjacency order.
+func (g *Graph) Reverse() *Graph {
+	r := NewGraph(g.V)
+	for u, adj := range g.Adj {
+		for _, e := range adj {
+			r.AddEdge(e.To, u, e.Weight)
 		}
-		sizes[u] = sz
-		currentID += sz
 	}
+	return r
+}
 
-	newG := NewGraph(currentID)
-	newToOrigin := make([]int, currentID)
+// EdgeTriple is a directed weighted edge given by both endpoints.
+type EdgeTriple struct {
+	From   int
+	To     int
+	Weight float64
+}
 
-	// Build Cycles and internal mappings
-	// Map (u, v) edge to specific index in u's cycle (outgoing) and v's cycle (incoming)
+// AddEdges appends edges in order, as repeated AddEdge calls would. Each
+// source's adjacency list is grown once. If any endpoint is out of range an
+// error is returned and the graph is left unchanged.
+func (g *Graph) AddEdges(edges []EdgeTriple) error {
+	extra := make(map[int]int)
+	for i, e := range edges {
+		if e.From < 0 || e.From >= g.V || e.To < 0 || e.To >= g.V {
+			return fmt.Errorf("graph: edge %d (%d->%d) out of range [0,%d)", i, e.From, e.To, g.V)
+		}
+		extra[e.From]++
+	}
 
-	// We need to assign specific "slots" in the cycle for each edge.
-	// slots[u] tracks next available slot for node u.
-	slots := make([]int, g.V)
+	for u, n := range extra {
+		g.Adj[u] = slices.Grow(g.Adj[u], n)
+	}
+	for _, e := range edges {
+		g.Adj[e.From] = append(g.Adj[e.From], Edge{To: e.To, Weight: e.Weight})
+	}
+	return nil
+}
 
-	// Create zero-weight cycles/chains
-	for u := 0; u < g.V; u++ {
-		start := starts[u]
-		sz := sizes[u]
-		for i := 0; i < sz; i++ {
-			curr := start + i
-			next := start + (i+1)%sz
-			newG.AddEdge(curr, next, 0)
-			newToOrigin[curr] = u
-		}
+// TransformedGraph holds the new graph and mapping data.
+type TransformedGraph struct {
+	G           *Graph
+	OriginalTo  []int // Map original ID -> node carrying its distance (cycle start for CycleReducer)
+	NewToOrigin []int // Map new ID -> Original ID
+}
+
+// StartNode returns the transformed node carrying the distance of original
+// vertex v: pass it to Run as a source, or index the result with it to read
+// v's distance. It panics if v is not a vertex of the original graph.
+func (tg *TransformedGraph) StartNode(v int) int {
+	if v < 0 || v >= len(tg.OriginalTo) {
+		panic(fmt.Sprintf("graph: original vertex %d out of range [0,%d)", v, len(tg.OriginalTo)))
 	}
+	return tg.OriginalTo[v]
+}
 
-	// Add real edges
-	for u := 0; u < g.V; u++ {
-		for _, e := range g.Adj[u] {
-			v := e.To
-			w := e.Weight
+// OriginalOf returns the original vertex that transformed node x belongs to.
+// It panics if x is not a node of the transformed graph.
+func (tg *TransformedGraph) OriginalOf(x int) int {
+	if x < 0 || x >= len(tg.NewToOrigin) {
+		panic(fmt.Sprintf("graph: transformed node %d out of range [0,%d)", x, len(tg.NewToOrigin)))
+	}
+	return tg.NewToOrigin[x]
+}
+
+// ToConstantDegree implements the transformation described in the paper
+// using CycleReducer.
+func (g *Graph) ToConstantDegree() *TransformedGraph {
+	return CycleReducer{}.Reduce(g)
+}
 
-			// u's slot for this outgoing edge
-			uSlot := slots[u]
-			slots[u]++
-			uNode := starts[u] + uSlot
+// ToConstantDegreeE is ToConstantDegree with a size check: it returns an
+// error instead of building the graph when the transformed vertex count would
+// exceed MaxTransformedVertices.
+func (g *Graph) ToConstantDegreeE() (*TransformedGraph, error) {
+	outDegree := make([]int, g.V)
+	for u := range outDegree {
+		outDegree[u] = g.OutDegree(u)
+	}
+	inDegree := g.InDegrees()
 
-			// v's slot for this incoming edge
-			vSlot := slots[v]
-			slots[v]++
-			vNode := starts[v] + vSlot
+	if _, err := transformedVertices(outDegree, inDegree, MaxTransformedVertices); err != nil {
+		return nil, err
+	}
+	return g.ToConstantDegree(), nil
+}
 
-			newG.AddEdge(uNode, vNode, w)
+// transformedVertices sums max(1, out+in) over all vertices, failing as soon
+// as the total would exceed limit. Every step is checked against limit before
+// adding, so the sum cannot overflow.
+func transformedVertices(outDegree, inDegree []int, limit int) (int, error) {
+	total := 0
+	for u := range outDegree {
+		if outDegree[u] > limit-inDegree[u] {
+			return 0, fmt.Errorf("graph: vertex %d has degree above %d", u, limit)
+		}
+		sz := max(1, outDegree[u]+inDegree[u])
+		if sz > limit-total {
+			return 0, fmt.Errorf("graph: transformed graph would exceed %d vertices", limit)
 		}
+		total += sz
 	}
+	return total, nil
+}
 
-	return &TransformedGraph{
-		G:           newG,
-		OriginalTo:  starts,
-		NewToOrigin: newToOrigin,
+// TransformedSize returns the vertex and edge counts ToConstantDegree would
+// produce, without building the graph. Each vertex becomes a cycle of
+// max(1, in+out) nodes with one zero-weight edge per node, and every original
+// edge is kept once.
+func (g *Graph) TransformedSize() (vertices, edges int) {
+	inDegree := g.InDegrees()
+	for u := 0; u < g.V; u++ {
+		vertices += max(1, g.OutDegree(u)+inDegree[u])
 	}
+	return vertices, vertices + g.NumEdges()
 }
 
 // MapDistances converts distances from the transformed graph back to the original.
 // If target is provided with enough capacity, it will be reused to avoid allocation.
+// A +Inf entry is reported as math.MaxFloat64, the solver's unreachable marker.
 func (tg *TransformedGraph) MapDistances(dist []float64, target ...[]float64) []float64 {
 	var res []float64
 	if len(target) > 0 && cap(target[0]) >= len(tg.OriginalTo) {
@@ -144,6 +207,20 @@ func (tg *TransformedGraph) MapDistances(dist []float64, target ...[]float64) []
 		// The distance to original node i is the min distance to any node in its cycle
 		// Or simply the distance to the "start" node of the cycle (since internal weights are 0)
 		res[i] = dist[startNode]
+		if math.IsInf(res[i], 1) {
+			res[i] = math.MaxFloat64
+		}
 	}
 	return res
 }
+
+// SortAdjacency stably sorts every node's edges by target. Solutions are
+// unchanged; relaxation then visits neighbours in memory order, which tends
+// to help cache locality and branch prediction on large transformed graphs.
+func (tg *TransformedGraph) SortAdjacency() {
+	for _, adj := range tg.G.Adj {
+		slices.SortStableFunc(adj, func(a, b Edge) int {
+			return a.To - b.To
+		})
+	}
+}
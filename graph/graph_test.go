@@ -0,0 +1,238 @@
+package graph
+
+import (
+	"math"
+	"math/rand"
+	"slices"
+	"testing"
+)
+
+func TestTransformedSize(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		n := 1 + rng.Intn(200)
+		g := NewGraph(n)
+		for i := 0; i < rng.Intn(5*n); i++ {
+			g.AddEdge(rng.Intn(n), rng.Intn(n), rng.Float64())
+		}
+
+		v, e := g.TransformedSize()
+		tg := g.ToConstantDegree()
+		if v != tg.G.V || e != tg.G.NumEdges() {
+			t.Errorf("seed %d: predicted (%d, %d), got (%d, %d)", seed, v, e, tg.G.V, tg.G.NumEdges())
+		}
+	}
+}
+
+func TestLabeledGraph(t *testing.T) {
+	lg := NewLabeledGraph()
+	lg.AddEdge("a", "b", 1)
+	lg.AddEdge("b", "c", 2)
+	lg.AddEdge("a", "c", 5)
+
+	if lg.G.V != 3 {
+		t.Fatalf("V = %d, want 3", lg.G.V)
+	}
+	for i, label := range []string{"a", "b", "c"} {
+		if idx, ok := lg.Index(label); !ok || idx != i {
+			t.Errorf("Index(%q) = %d, %v", label, idx, ok)
+		}
+		if lg.Label(i) != label {
+			t.Errorf("Label(%d) = %q, want %q", i, lg.Label(i), label)
+		}
+	}
+	if len(lg.G.Adj[0]) != 2 {
+		t.Errorf("a has %d edges, want 2", len(lg.G.Adj[0]))
+	}
+	if _, ok := lg.Index("z"); ok {
+		t.Error("Index of unknown label reported ok")
+	}
+}
+
+func TestTransformedVerticesGuard(t *testing.T) {
+	tests := []struct {
+		name     string
+		out, in  []int
+		limit    int
+		want     int
+		wantFail bool
+	}{
+		{"fits", []int{2, 0, 1}, []int{1, 0, 2}, 10, 7, false},
+		{"exactly at limit", []int{5, 5}, []int{0, 0}, 10, 10, false},
+		{"sum over limit", []int{5, 5}, []int{0, 1}, 10, 0, true},
+		{"isolated vertices count", []int{0, 0, 0}, []int{0, 0, 0}, 2, 0, true},
+		{"degree would overflow", []int{math.MaxInt}, []int{math.MaxInt}, math.MaxInt, 0, true},
+		{"sum would overflow", []int{math.MaxInt - 1, 2}, []int{0, 0}, math.MaxInt, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := transformedVertices(tt.out, tt.in, tt.limit)
+			if tt.wantFail {
+				if err == nil {
+					t.Fatalf("got %d, want error", got)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("got (%d, %v), want %d", got, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestToConstantDegreeE(t *testing.T) {
+	g := NewGraph(3)
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 1)
+
+	tg, err := g.ToConstantDegreeE()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if v, _ := g.TransformedSize(); tg.G.V != v {
+		t.Errorf("V = %d, want %d", tg.G.V, v)
+	}
+}
+
+func TestAddEdges(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	const n = 50
+	edges := make([]EdgeTriple, 300)
+	for i := range edges {
+		edges[i] = EdgeTriple{From: rng.Intn(n), To: rng.Intn(n), Weight: rng.Float64()}
+	}
+
+	want := NewGraph(n)
+	want.AddEdge(3, 4, 1) // pre-existing edges stay first
+	for _, e := range edges {
+		want.AddEdge(e.From, e.To, e.Weight)
+	}
+
+	got := NewGraph(n)
+	got.AddEdge(3, 4, 1)
+	if err := got.AddEdges(edges); err != nil {
+		t.Fatal(err)
+	}
+	if !Equal(got, want) {
+		t.Errorf("adjacency differs from AddEdge: %v", Diff(got, want))
+	}
+}
+
+func TestAddEdgesOutOfRange(t *testing.T) {
+	for _, bad := range []EdgeTriple{{From: -1, To: 0}, {From: 0, To: 3}, {From: 3, To: 0}} {
+		g := NewGraph(3)
+		if err := g.AddEdges([]EdgeTriple{{From: 0, To: 1, Weight: 1}, bad}); err == nil {
+			t.Errorf("%+v: expected error", bad)
+		}
+		if len(g.Adj[0]) != 0 {
+			t.Errorf("%+v: graph modified despite error", bad)
+		}
+	}
+}
+
+func TestNumEdges(t *testing.T) {
+	g := NewGraph(4)
+	if g.NumEdges() != 0 {
+		t.Fatalf("empty graph: NumEdges = %d", g.NumEdges())
+	}
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(0, 2, 1)
+	g.AddEdge(2, 0, 1)
+	g.AddEdge(1, 1, 1)
+	g.AddEdge(0, 1, 3) // parallel edges count separately
+	if g.NumEdges() != 5 {
+		t.Errorf("NumEdges = %d, want 5", g.NumEdges())
+	}
+
+	// Each vertex becomes a cycle of max(1, in+out) nodes, one zero-weight
+	// edge per node, plus the 5 original edges: in+out is 4, 4, 2, 0.
+	tg := g.ToConstantDegree()
+	if got, want := tg.G.NumEdges(), 4+4+2+1+5; got != want {
+		t.Errorf("transformed NumEdges = %d, want %d", got, want)
+	}
+}
+
+func TestReverse(t *testing.T) {
+	g := NewGraph(3)
+	g.AddEdge(0, 1, 2)
+	g.AddEdge(0, 2, 5)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 2, 4)
+
+	r := g.Reverse()
+	want := [][]Edge{
+		nil,
+		{{To: 0, Weight: 2}},
+		{{To: 0, Weight: 5}, {To: 1, Weight: 1}, {To: 2, Weight: 4}},
+	}
+	if r.V != g.V {
+		t.Fatalf("V = %d, want %d", r.V, g.V)
+	}
+	for v := range want {
+		if !slices.Equal(r.Adj[v], want[v]) {
+			t.Errorf("Adj[%d] = %v, want %v", v, r.Adj[v], want[v])
+		}
+	}
+	if !Equal(r.Reverse(), g) {
+		t.Error("reversing twice did not give back g")
+	}
+}
+
+func TestDegrees(t *testing.T) {
+	g := NewGraph(4)
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(0, 2, 1)
+	g.AddEdge(0, 1, 2) // parallel edge
+	g.AddEdge(2, 2, 0) // self-loop counts once each way
+	g.AddEdge(3, 0, 1)
+
+	wantOut := []int{3, 0, 1, 1}
+	wantIn := []int{1, 2, 2, 0}
+	all := g.InDegrees()
+	for v := 0; v < g.V; v++ {
+		if got := g.OutDegree(v); got != wantOut[v] {
+			t.Errorf("OutDegree(%d) = %d, want %d", v, got, wantOut[v])
+		}
+		if got := g.InDegree(v); got != wantIn[v] {
+			t.Errorf("InDegree(%d) = %d, want %d", v, got, wantIn[v])
+		}
+		if all[v] != wantIn[v] {
+			t.Errorf("InDegrees()[%d] = %d, want %d", v, all[v], wantIn[v])
+		}
+	}
+}
+
+func TestStartNodeOriginalOf(t *testing.T) {
+	rng := rand.New(rand.NewSource(4))
+	g := NewGraph(100)
+	for i := 0; i < 400; i++ {
+		g.AddEdge(rng.Intn(100), rng.Intn(100), 1)
+	}
+
+	for name, tg := range map[string]*TransformedGraph{
+		"cycle": g.ToConstantDegree(),
+		"split": SplitReducer{}.Reduce(g),
+	} {
+		for v := 0; v < g.V; v++ {
+			if got := tg.OriginalOf(tg.StartNode(v)); got != v {
+				t.Errorf("%s: OriginalOf(StartNode(%d)) = %d", name, v, got)
+			}
+		}
+
+		for _, bad := range []func(){
+			func() { tg.StartNode(-1) },
+			func() { tg.StartNode(g.V) },
+			func() { tg.OriginalOf(tg.G.V) },
+		} {
+			func() {
+				defer func() {
+					if recover() == nil {
+						t.Errorf("%s: out-of-range access did not panic", name)
+					}
+				}()
+				bad()
+			}()
+		}
+	}
+}
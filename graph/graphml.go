@@ -0,0 +1,114 @@
+package graph
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+type graphMLKey struct {
+	ID       string `xml:"id,attr"`
+	For      string `xml:"for,attr"`
+	AttrName string `xml:"attr.name,attr"`
+	AttrType string `xml:"attr.type,attr"`
+}
+
+type graphMLData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+
+type graphMLNode struct {
+	ID   string        `xml:"id,attr"`
+	Data []graphMLData `xml:"data"`
+}
+
+type graphMLEdge struct {
+	Source string        `xml:"source,attr"`
+	Target string        `xml:"target,attr"`
+	Data   []graphMLData `xml:"data"`
+}
+
+type graphMLGraph struct {
+	EdgeDefault string        `xml:"edgedefault,attr"`
+	Nodes       []graphMLNode `xml:"node"`
+	Edges       []graphMLEdge `xml:"edge"`
+}
+
+type graphMLDoc struct {
+	XMLName xml.Name     `xml:"graphml"`
+	XMLNS   string       `xml:"xmlns,attr"`
+	Keys    []graphMLKey `xml:"key"`
+	Graph   graphMLGraph `xml:"graph"`
+}
+
+// WriteGraphML writes g as a directed GraphML document with a "weight"
+// attribute on every edge. Nodes are named n0..n{V-1}.
+func (g *Graph) WriteGraphML(w io.Writer) error {
+	return g.WriteGraphMLMeta(w, nil)
+}
+
+// WriteGraphMLMeta is WriteGraphML that also writes meta's labels and
+// coordinates as "label", "x" and "y" node attributes. Empty or missing
+// fields of meta are skipped; meta may be nil.
+func (g *Graph) WriteGraphMLMeta(w io.Writer, meta *Meta) error {
+	doc := graphMLDoc{
+		XMLNS: "http://graphml.graphdrawing.org/xmlns",
+		Keys:  []graphMLKey{{ID: "weight", For: "edge", AttrName: "weight", AttrType: "double"}},
+		Graph: graphMLGraph{EdgeDefault: "directed", Nodes: make([]graphMLNode, g.V)},
+	}
+	hasLabels := meta != nil && len(meta.Labels) == g.V
+	hasCoords := meta != nil && len(meta.X) == g.V && len(meta.Y) == g.V
+	if hasLabels {
+		doc.Keys = append(doc.Keys, graphMLKey{ID: "label", For: "node", AttrName: "label", AttrType: "string"})
+	}
+	if hasCoords {
+		doc.Keys = append(doc.Keys,
+			graphMLKey{ID: "x", For: "node", AttrName: "x", AttrType: "double"},
+			graphMLKey{ID: "y", For: "node", AttrName: "y", AttrType: "double"})
+	}
+
+	for v := range doc.Graph.Nodes {
+		n := graphMLNode{ID: graphMLNodeID(v)}
+		if hasLabels && meta.Labels[v] != "" {
+			n.Data = append(n.Data, graphMLData{Key: "label", Value: meta.Labels[v]})
+		}
+		if hasCoords {
+			n.Data = append(n.Data,
+				graphMLData{Key: "x", Value: formatFloat(meta.X[v])},
+				graphMLData{Key: "y", Value: formatFloat(meta.Y[v])})
+		}
+		doc.Graph.Nodes[v] = n
+	}
+
+	doc.Graph.Edges = make([]graphMLEdge, 0, g.NumEdges())
+	for u, adj := range g.Adj {
+		for _, e := range adj {
+			doc.Graph.Edges = append(doc.Graph.Edges, graphMLEdge{
+				Source: graphMLNodeID(u),
+				Target: graphMLNodeID(e.To),
+				Data:   []graphMLData{{Key: "weight", Value: formatFloat(e.Weight)}},
+			})
+		}
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return fmt.Errorf("graph: writing GraphML: %w", err)
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("graph: writing GraphML: %w", err)
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
+
+func graphMLNodeID(v int) string {
+	return "n" + strconv.Itoa(v)
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
@@ -0,0 +1,79 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestWriteGraphMLWellFormed(t *testing.T) {
+	g := NewGraph(3)
+	g.AddEdge(0, 1, 1.5)
+	g.AddEdge(1, 2, 2)
+	meta := &Meta{Labels: []string{"a<b", "", "c&d"}, X: []float64{0, 1, 2}, Y: []float64{3, 4, 5}}
+
+	var buf bytes.Buffer
+	if err := g.WriteGraphMLMeta(&buf, meta); err != nil {
+		t.Fatal(err)
+	}
+
+	dec := xml.NewDecoder(bytes.NewReader(buf.Bytes()))
+	for {
+		_, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("malformed XML: %v\n%s", err, buf.String())
+		}
+	}
+	if !strings.Contains(buf.String(), `edgedefault="directed"`) {
+		t.Error("graph is not marked directed")
+	}
+}
+
+// TestGraphMLRoundTrip reads the document back with encoding/xml and
+// rebuilds the graph from node ids and edge weights.
+func TestGraphMLRoundTrip(t *testing.T) {
+	g := NewGraph(4)
+	g.AddEdge(0, 1, 1.25)
+	g.AddEdge(0, 3, 0)
+	g.AddEdge(2, 0, 7)
+	g.AddEdge(3, 3, 0.1)
+
+	var buf bytes.Buffer
+	if err := g.WriteGraphML(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	var doc graphMLDoc
+	if err := xml.Unmarshal(buf.Bytes(), &doc); err != nil {
+		t.Fatal(err)
+	}
+	if len(doc.Graph.Nodes) != g.V {
+		t.Fatalf("%d nodes, want %d", len(doc.Graph.Nodes), g.V)
+	}
+	index := make(map[string]int)
+	for i, n := range doc.Graph.Nodes {
+		index[n.ID] = i
+	}
+
+	back := NewGraph(len(doc.Graph.Nodes))
+	for _, e := range doc.Graph.Edges {
+		if len(e.Data) != 1 || e.Data[0].Key != "weight" {
+			t.Fatalf("edge %s->%s: data %+v, want one weight", e.Source, e.Target, e.Data)
+		}
+		w, err := strconv.ParseFloat(e.Data[0].Value, 64)
+		if err != nil {
+			t.Fatal(err)
+		}
+		back.AddEdge(index[e.Source], index[e.Target], w)
+	}
+	if !Equal(g, back) {
+		t.Errorf("round trip changed graph: %v", Diff(g, back))
+	}
+}
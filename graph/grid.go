@@ -0,0 +1,53 @@
+package graph
+
+import "math"
+
+// NewGrid builds a w×h grid graph and returns it with the function mapping
+// cell (x, y) to its vertex, y*w + x. Each open cell has an edge to every
+// open 4-neighbour, or 8-neighbour when diagonal is set, weighted by the cost
+// of the cell it enters; diagonal steps cost math.Sqrt2 times as much. A cell
+// whose cost is +Inf or math.MaxFloat64 is blocked and has no edges, and a
+// diagonal step is left out if either cell it cuts past is blocked.
+//
+// With every open cost at least 1, the Manhattan distance between cells is
+// an admissible A* heuristic for a 4-connected grid and the Chebyshev
+// distance for an 8-connected one.
+func NewGrid(w, h int, diagonal bool, cost func(x, y int) float64) (*Graph, func(x, y int) int) {
+	index := func(x, y int) int { return y*w + x }
+	costs := make([]float64, w*h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			costs[index(x, y)] = cost(x, y)
+		}
+	}
+	open := func(x, y int) bool {
+		return x >= 0 && x < w && y >= 0 && y < h && costs[index(x, y)] < math.MaxFloat64
+	}
+
+	g := NewGraph(w * h)
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			if !open(x, y) {
+				continue
+			}
+			u := index(x, y)
+			for dy := -1; dy <= 1; dy++ {
+				for dx := -1; dx <= 1; dx++ {
+					nx, ny := x+dx, y+dy
+					if (dx == 0 && dy == 0) || !open(nx, ny) {
+						continue
+					}
+					wt := costs[index(nx, ny)]
+					if dx != 0 && dy != 0 {
+						if !diagonal || !open(nx, y) || !open(x, ny) {
+							continue
+						}
+						wt *= math.Sqrt2
+					}
+					g.AddEdge(u, index(nx, ny), wt)
+				}
+			}
+		}
+	}
+	return g, index
+}
@@ -0,0 +1,52 @@
+package graph
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewGrid(t *testing.T) {
+	// 3x3 with the centre-right cell blocked:
+	//   . . .
+	//   . . #
+	//   . . .
+	cost := func(x, y int) float64 {
+		if x == 2 && y == 1 {
+			return math.Inf(1)
+		}
+		return float64(1 + x)
+	}
+
+	g, index := NewGrid(3, 3, false, cost)
+	if g.V != 9 || index(2, 1) != 5 {
+		t.Fatalf("V = %d, index(2, 1) = %d", g.V, index(2, 1))
+	}
+	if d := g.OutDegree(index(2, 1)); d != 0 {
+		t.Errorf("blocked cell has %d out-edges", d)
+	}
+	if d := g.InDegree(index(2, 1)); d != 0 {
+		t.Errorf("blocked cell has %d in-edges", d)
+	}
+	if d := g.OutDegree(index(1, 1)); d != 3 {
+		t.Errorf("centre out-degree = %d, want 3", d)
+	}
+	for _, e := range g.Adj[index(0, 0)] {
+		if e.To == index(1, 0) && e.Weight != 2 {
+			t.Errorf("edge into (1, 0) weighs %v, want its cost 2", e.Weight)
+		}
+	}
+
+	g, index = NewGrid(3, 3, true, cost)
+	if d := g.OutDegree(index(1, 1)); d != 5 {
+		t.Errorf("diagonal centre out-degree = %d, want 5", d)
+	}
+	// Both right-hand diagonals from the centre cut past the blocked cell.
+	for _, e := range g.Adj[index(1, 1)] {
+		if e.To == index(2, 0) || e.To == index(2, 2) {
+			t.Errorf("diagonal edge to %d cuts past the blocked cell", e.To)
+		}
+		if e.To == index(0, 0) && e.Weight != math.Sqrt2 {
+			t.Errorf("diagonal edge into (0, 0) weighs %v, want Sqrt2", e.Weight)
+		}
+	}
+}
@@ -0,0 +1,149 @@
+package graph
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+)
+
+// Meta holds per-vertex labels and coordinates, indexed by vertex ID.
+type Meta struct {
+	Labels []string
+	X, Y   []float64
+}
+
+// Dist returns the straight-line distance between u and v. It is an
+// admissible A* heuristic when no edge is shorter than its endpoints' distance.
+func (m *Meta) Dist(u, v int) float64 {
+	return math.Hypot(m.X[u]-m.X[v], m.Y[u]-m.Y[v])
+}
+
+type jsonVertex struct {
+	ID    int     `json:"id"`
+	Label string  `json:"label,omitempty"`
+	X     float64 `json:"x"`
+	Y     float64 `json:"y"`
+}
+
+type jsonEdge struct {
+	From int     `json:"from"`
+	To   int     `json:"to"`
+	W    float64 `json:"w"`
+}
+
+type jsonGraph struct {
+	Vertices []jsonVertex `json:"vertices"`
+	Edges    []jsonEdge   `json:"edges"`
+}
+
+// LoadJSON reads a graph in the form
+//
+//	{"vertices":[{"id":0,"label":"A","x":1,"y":2}], "edges":[{"from":0,"to":1,"w":3.5}]}
+//
+// Vertex IDs must be exactly 0..len(vertices)-1, in any order.
+func LoadJSON(r io.Reader) (*Graph, *Meta, error) {
+	var jg jsonGraph
+	if err := json.NewDecoder(r).Decode(&jg); err != nil {
+		return nil, nil, fmt.Errorf("graph: decoding JSON: %w", err)
+	}
+
+	n := len(jg.Vertices)
+	meta := &Meta{
+		Labels: make([]string, n),
+		X:      make([]float64, n),
+		Y:      make([]float64, n),
+	}
+	seen := make([]bool, n)
+	for _, v := range jg.Vertices {
+		if v.ID < 0 || v.ID >= n {
+			return nil, nil, fmt.Errorf("graph: vertex id %d out of range [0,%d)", v.ID, n)
+		}
+		if seen[v.ID] {
+			return nil, nil, fmt.Errorf("graph: duplicate vertex id %d", v.ID)
+		}
+		seen[v.ID] = true
+		meta.Labels[v.ID] = v.Label
+		meta.X[v.ID] = v.X
+		meta.Y[v.ID] = v.Y
+	}
+
+	g := NewGraph(n)
+	for i, e := range jg.Edges {
+		if e.From < 0 || e.From >= n || e.To < 0 || e.To >= n {
+			return nil, nil, fmt.Errorf("graph: edge %d (%d->%d) references unknown vertex", i, e.From, e.To)
+		}
+		g.AddEdge(e.From, e.To, e.W)
+	}
+
+	return g, meta, nil
+}
+
+// SaveJSON writes g in the format read by LoadJSON. meta may be nil.
+func SaveJSON(w io.Writer, g *Graph, meta *Meta) error {
+	jg := jsonGraph{
+		Vertices: make([]jsonVertex, g.V),
+		Edges:    make([]jsonEdge, 0),
+	}
+	for u := 0; u < g.V; u++ {
+		jg.Vertices[u].ID = u
+		if meta != nil {
+			jg.Vertices[u].Label = meta.Labels[u]
+			jg.Vertices[u].X = meta.X[u]
+			jg.Vertices[u].Y = meta.Y[u]
+		}
+		for _, e := range g.Adj[u] {
+			jg.Edges = append(jg.Edges, jsonEdge{From: u, To: e.To, W: e.Weight})
+		}
+	}
+	return json.NewEncoder(w).Encode(jg)
+}
+
+// compactGraph is the wire form of Graph's own JSON encoding.
+type compactGraph struct {
+	V     int         `json:"v"`
+	Edges [][]float64 `json:"edges"`
+}
+
+// MarshalJSON encodes g as {"v":N,"edges":[[u,v,w],...]}, listing edges in
+// adjacency order.
+func (g Graph) MarshalJSON() ([]byte, error) {
+	cg := compactGraph{V: g.V, Edges: make([][]float64, 0, g.NumEdges())}
+	for u, adj := range g.Adj {
+		for _, e := range adj {
+			cg.Edges = append(cg.Edges, []float64{float64(u), float64(e.To), e.Weight})
+		}
+	}
+	return json.Marshal(cg)
+}
+
+// UnmarshalJSON decodes the form written by MarshalJSON, replacing g. Edge
+// endpoints must be integers in [0, v).
+func (g *Graph) UnmarshalJSON(data []byte) error {
+	var cg compactGraph
+	if err := json.Unmarshal(data, &cg); err != nil {
+		return fmt.Errorf("graph: decoding JSON: %w", err)
+	}
+	if cg.V < 0 {
+		return fmt.Errorf("graph: negative vertex count %d", cg.V)
+	}
+
+	edges := make([]EdgeTriple, len(cg.Edges))
+	for i, e := range cg.Edges {
+		if len(e) != 3 {
+			return fmt.Errorf("graph: edge %d has %d fields, want [u,v,w]", i, len(e))
+		}
+		u, v := int(e[0]), int(e[1])
+		if float64(u) != e[0] || float64(v) != e[1] {
+			return fmt.Errorf("graph: edge %d has non-integer endpoints %v, %v", i, e[0], e[1])
+		}
+		edges[i] = EdgeTriple{From: u, To: v, Weight: e[2]}
+	}
+
+	ng := NewGraph(cg.V)
+	if err := ng.AddEdges(edges); err != nil {
+		return err
+	}
+	*g = *ng
+	return nil
+}
@@ -0,0 +1,133 @@
+package graph
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/rand"
+	"strings"
+	"testing"
+)
+
+func TestLoadJSON(t *testing.T) {
+	in := `{"vertices":[{"id":1,"label":"B","x":3,"y":4},{"id":0,"label":"A","x":0,"y":0}],
+		"edges":[{"from":0,"to":1,"w":3.5}]}`
+	g, meta, err := LoadJSON(strings.NewReader(in))
+	if err != nil {
+		t.Fatalf("LoadJSON: %v", err)
+	}
+	if g.V != 2 || len(g.Adj[0]) != 1 || g.Adj[0][0] != (Edge{To: 1, Weight: 3.5}) {
+		t.Fatalf("unexpected graph %+v", g)
+	}
+	if meta.Labels[0] != "A" || meta.Labels[1] != "B" {
+		t.Errorf("labels = %v", meta.Labels)
+	}
+	if d := meta.Dist(0, 1); d != 5 {
+		t.Errorf("Dist(0, 1) = %v, want 5", d)
+	}
+}
+
+func TestLoadJSONErrors(t *testing.T) {
+	cases := map[string]string{
+		"unknown edge target": `{"vertices":[{"id":0}],"edges":[{"from":0,"to":1,"w":1}]}`,
+		"id out of range":     `{"vertices":[{"id":2}],"edges":[]}`,
+		"duplicate id":        `{"vertices":[{"id":0},{"id":0}],"edges":[]}`,
+		"malformed":           `{"vertices":`,
+	}
+	for name, in := range cases {
+		if _, _, err := LoadJSON(strings.NewReader(in)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
+
+func TestJSONRoundTrip(t *testing.T) {
+	g := NewGraph(3)
+	g.AddEdge(0, 1, 1.5)
+	g.AddEdge(1, 2, 0)
+	g.AddEdge(2, 0, 7.25)
+	meta := &Meta{
+		Labels: []string{"a", "b", "c"},
+		X:      []float64{0, 1, 2},
+		Y:      []float64{-1, 0.5, 3},
+	}
+
+	var buf bytes.Buffer
+	if err := SaveJSON(&buf, g, meta); err != nil {
+		t.Fatalf("SaveJSON: %v", err)
+	}
+	g2, meta2, err := LoadJSON(&buf)
+	if err != nil {
+		t.Fatalf("LoadJSON: %v", err)
+	}
+
+	if g2.V != g.V {
+		t.Fatalf("V = %d, want %d", g2.V, g.V)
+	}
+	for u := range g.Adj {
+		if len(g2.Adj[u]) != len(g.Adj[u]) {
+			t.Fatalf("vertex %d: %d edges, want %d", u, len(g2.Adj[u]), len(g.Adj[u]))
+		}
+		for i, e := range g.Adj[u] {
+			if g2.Adj[u][i] != e {
+				t.Errorf("vertex %d edge %d = %+v, want %+v", u, i, g2.Adj[u][i], e)
+			}
+		}
+	}
+	for v := 0; v < g.V; v++ {
+		if meta2.Labels[v] != meta.Labels[v] || meta2.X[v] != meta.X[v] || meta2.Y[v] != meta.Y[v] {
+			t.Errorf("vertex %d meta mismatch", v)
+		}
+	}
+}
+
+func TestGraphJSONRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(3))
+	g := NewGraph(40)
+	for i := 0; i < 150; i++ {
+		g.AddEdge(rng.Intn(40), rng.Intn(40), rng.Float64()*10)
+	}
+
+	data, err := json.Marshal(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var back Graph
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatal(err)
+	}
+	if !Equal(g, &back) {
+		t.Errorf("round trip changed graph: %v", Diff(g, &back))
+	}
+	for u := range back.Adj {
+		if cap(back.Adj[u]) != len(back.Adj[u]) {
+			t.Errorf("vertex %d: cap %d, len %d", u, cap(back.Adj[u]), len(back.Adj[u]))
+		}
+	}
+}
+
+func TestGraphJSONFormat(t *testing.T) {
+	g := NewGraph(2)
+	g.AddEdge(1, 0, 2.5)
+	data, err := json.Marshal(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"v":2,"edges":[[1,0,2.5]]}`; string(data) != want {
+		t.Errorf("got %s, want %s", data, want)
+	}
+}
+
+func TestGraphJSONRejectsBadEdges(t *testing.T) {
+	for _, in := range []string{
+		`{"v":2,"edges":[[0,2,1]]}`,
+		`{"v":2,"edges":[[-1,0,1]]}`,
+		`{"v":2,"edges":[[0.5,1,1]]}`,
+		`{"v":-1,"edges":[]}`,
+		`{"v":2,"edges":[[0,1]]}`,
+	} {
+		var g Graph
+		if err := json.Unmarshal([]byte(in), &g); err == nil {
+			t.Errorf("%s: expected error, got %+v", in, g)
+		}
+	}
+}
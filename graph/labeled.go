@@ -0,0 +1,48 @@
+package graph
+
+// LabeledGraph wraps a Graph whose vertices are named by strings. Indices are
+// assigned in order of first appearance.
+type LabeledGraph struct {
+	G      *Graph
+	index  map[string]int
+	labels []string
+}
+
+// NewLabeledGraph creates an empty labeled graph.
+func NewLabeledGraph() *LabeledGraph {
+	return &LabeledGraph{
+		G:     NewGraph(0),
+		index: make(map[string]int),
+	}
+}
+
+// AddVertex returns the index of label, adding it if it is new.
+func (lg *LabeledGraph) AddVertex(label string) int {
+	if i, ok := lg.index[label]; ok {
+		return i
+	}
+	i := lg.G.V
+	lg.index[label] = i
+	lg.labels = append(lg.labels, label)
+	lg.G.V++
+	lg.G.Adj = append(lg.G.Adj, nil)
+	return i
+}
+
+// AddEdge adds a weighted edge between two labels, creating them as needed.
+func (lg *LabeledGraph) AddEdge(uLabel, vLabel string, w float64) {
+	u := lg.AddVertex(uLabel)
+	v := lg.AddVertex(vLabel)
+	lg.G.AddEdge(u, v, w)
+}
+
+// Index returns the vertex index of label and whether it exists.
+func (lg *LabeledGraph) Index(label string) (int, bool) {
+	i, ok := lg.index[label]
+	return i, ok
+}
+
+// Label returns the label of vertex i.
+func (lg *LabeledGraph) Label(i int) string {
+	return lg.labels[i]
+}
@@ -0,0 +1,58 @@
+package graph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// LoadAdjMatrix reads an n×n matrix of whitespace-separated weights, one row
+// per line, where cell (u, v) is the weight of edge u->v. A cell equal to inf
+// or negative means no edge, and the diagonal is ignored. n is taken from the
+// first row. Blank lines are skipped; errors name the 1-based line.
+func LoadAdjMatrix(r io.Reader, inf float64) (*Graph, error) {
+	sc := bufio.NewScanner(r)
+	var g *Graph
+	row := 0
+
+	for line := 1; sc.Scan(); line++ {
+		fields := strings.Fields(sc.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if g == nil {
+			g = NewGraph(len(fields))
+		}
+		if row == g.V {
+			return nil, fmt.Errorf("graph: line %d: more than %d rows", line, g.V)
+		}
+		if len(fields) != g.V {
+			return nil, fmt.Errorf("graph: line %d: want %d columns, got %d", line, g.V, len(fields))
+		}
+
+		for v, f := range fields {
+			w, err := strconv.ParseFloat(f, 64)
+			if err != nil || w != w {
+				return nil, fmt.Errorf("graph: line %d: invalid weight %q", line, f)
+			}
+			if v == row || w == inf || w < 0 {
+				continue
+			}
+			g.AddEdge(row, v, w)
+		}
+		row++
+	}
+	if err := sc.Err(); err != nil {
+		return nil, fmt.Errorf("graph: reading matrix: %w", err)
+	}
+
+	if g == nil {
+		return NewGraph(0), nil
+	}
+	if row != g.V {
+		return nil, fmt.Errorf("graph: got %d rows, want %d", row, g.V)
+	}
+	return g, nil
+}
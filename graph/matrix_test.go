@@ -0,0 +1,65 @@
+package graph
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestLoadAdjMatrix(t *testing.T) {
+	in := `
+0   2   -1  7
+9   5   1   0
+-1  -1  0   3.5
+
+4   Inf -1  0
+`
+	g, err := LoadAdjMatrix(strings.NewReader(in), math.Inf(1))
+	if err != nil {
+		t.Fatalf("LoadAdjMatrix: %v", err)
+	}
+	if g.V != 4 {
+		t.Fatalf("V = %d, want 4", g.V)
+	}
+
+	want := map[int][]Edge{
+		0: {{To: 1, Weight: 2}, {To: 3, Weight: 7}},
+		1: {{To: 0, Weight: 9}, {To: 2, Weight: 1}, {To: 3, Weight: 0}}, // diagonal 5 ignored
+		2: {{To: 3, Weight: 3.5}},
+		3: {{To: 0, Weight: 4}},
+	}
+	for u := 0; u < g.V; u++ {
+		if len(g.Adj[u]) != len(want[u]) {
+			t.Fatalf("vertex %d: edges %+v, want %+v", u, g.Adj[u], want[u])
+		}
+		for i, e := range want[u] {
+			if g.Adj[u][i] != e {
+				t.Errorf("vertex %d edge %d = %+v, want %+v", u, i, g.Adj[u][i], e)
+			}
+		}
+	}
+}
+
+func TestLoadAdjMatrixCustomInf(t *testing.T) {
+	g, err := LoadAdjMatrix(strings.NewReader("0 999\n999 0\n"), 999)
+	if err != nil {
+		t.Fatalf("LoadAdjMatrix: %v", err)
+	}
+	if len(g.Adj[0])+len(g.Adj[1]) != 0 {
+		t.Errorf("expected no edges, got %v", g.Adj)
+	}
+}
+
+func TestLoadAdjMatrixErrors(t *testing.T) {
+	for _, in := range []string{
+		"0 1\n1\n",
+		"0 1\n1 0\n1 1\n",
+		"0 1 2\n1 0 2\n",
+		"0 x\n1 0\n",
+		"0 NaN\n1 0\n",
+	} {
+		if _, err := LoadAdjMatrix(strings.NewReader(in), -1); err == nil {
+			t.Errorf("%q: expected error", in)
+		}
+	}
+}
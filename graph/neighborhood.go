@@ -0,0 +1,46 @@
+package graph
+
+import "slices"
+
+// Neighborhood returns the subgraph induced by the vertices within k hops of
+// source along outgoing edges, and the original index of each subgraph
+// vertex. Subgraph vertices are numbered in increasing original order, and
+// an edge is kept, with its weight, only if both endpoints are in the set.
+// Negative k is treated as 0.
+func (g *Graph) Neighborhood(source, k int) (*Graph, []int) {
+	seen := map[int]bool{source: true}
+	frontier := []int{source}
+	for d := 1; d <= k && len(frontier) > 0; d++ {
+		var next []int
+		for _, u := range frontier {
+			for _, e := range g.Adj[u] {
+				if !seen[e.To] {
+					seen[e.To] = true
+					next = append(next, e.To)
+				}
+			}
+		}
+		frontier = next
+	}
+
+	orig := make([]int, 0, len(seen))
+	for v := range seen {
+		orig = append(orig, v)
+	}
+	slices.Sort(orig)
+
+	index := make(map[int]int, len(orig))
+	for i, v := range orig {
+		index[v] = i
+	}
+
+	sub := NewGraph(len(orig))
+	for i, u := range orig {
+		for _, e := range g.Adj[u] {
+			if j, ok := index[e.To]; ok {
+				sub.AddEdge(i, j, e.Weight)
+			}
+		}
+	}
+	return sub, orig
+}
@@ -0,0 +1,45 @@
+package graph
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestNeighborhood(t *testing.T) {
+	//   0 -> 1 -> 2 -> 3
+	//   |         ^
+	//   v         |
+	//   4 ------> 5      6 -> 0 (points in, not reachable from 0)
+	g := NewGraph(7)
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 2)
+	g.AddEdge(2, 3, 3)
+	g.AddEdge(0, 4, 4)
+	g.AddEdge(4, 5, 5)
+	g.AddEdge(5, 2, 6)
+	g.AddEdge(2, 0, 7)
+	g.AddEdge(6, 0, 8)
+
+	sub, orig := g.Neighborhood(0, 2)
+	if !slices.Equal(orig, []int{0, 1, 2, 4, 5}) {
+		t.Fatalf("vertices = %v, want [0 1 2 4 5]", orig)
+	}
+
+	// Indices: 0->0, 1->1, 2->2, 4->3, 5->4. 2->3 and 6->0 leave the set.
+	want := NewGraph(5)
+	want.AddEdge(0, 1, 1)
+	want.AddEdge(0, 3, 4)
+	want.AddEdge(1, 2, 2)
+	want.AddEdge(2, 0, 7)
+	want.AddEdge(3, 4, 5)
+	want.AddEdge(4, 2, 6)
+	if diff := Diff(want, sub); diff != nil {
+		t.Errorf("subgraph differs: %v", diff)
+	}
+
+	for k, wantV := range map[int][]int{-1: {0}, 0: {0}, 1: {0, 1, 4}, 3: {0, 1, 2, 3, 4, 5}} {
+		if _, got := g.Neighborhood(0, k); !slices.Equal(got, wantV) {
+			t.Errorf("k=%d: vertices %v, want %v", k, got, wantV)
+		}
+	}
+}
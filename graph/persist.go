@@ -0,0 +1,72 @@
+package graph
+
+import (
+	"encoding/gob"
+	"fmt"
+	"io"
+)
+
+// transformedVersion is bumped whenever the on-disk layout changes.
+const transformedVersion = 1
+
+type transformedFile struct {
+	Version     int
+	V           int
+	Adj         [][]Edge
+	OriginalTo  []int
+	NewToOrigin []int
+}
+
+// Save writes tg, including its vertex mappings, in a binary format that
+// LoadTransformed reads back.
+func (tg *TransformedGraph) Save(w io.Writer) error {
+	return gob.NewEncoder(w).Encode(transformedFile{
+		Version:     transformedVersion,
+		V:           tg.G.V,
+		Adj:         tg.G.Adj,
+		OriginalTo:  tg.OriginalTo,
+		NewToOrigin: tg.NewToOrigin,
+	})
+}
+
+// LoadTransformed reads a transformed graph written by Save.
+func LoadTransformed(r io.Reader) (*TransformedGraph, error) {
+	var f transformedFile
+	if err := gob.NewDecoder(r).Decode(&f); err != nil {
+		return nil, fmt.Errorf("graph: decoding transformed graph: %w", err)
+	}
+	if f.Version != transformedVersion {
+		return nil, fmt.Errorf("graph: unsupported transformed graph version %d", f.Version)
+	}
+	if len(f.Adj) != f.V || len(f.NewToOrigin) != f.V {
+		return nil, fmt.Errorf("graph: %d adjacency lists and %d origin entries for %d vertices",
+			len(f.Adj), len(f.NewToOrigin), f.V)
+	}
+	for u := range f.Adj {
+		for _, e := range f.Adj[u] {
+			if e.To < 0 || e.To >= f.V {
+				return nil, fmt.Errorf("graph: edge %d->%d out of range", u, e.To)
+			}
+		}
+	}
+	if len(f.OriginalTo) > f.V {
+		return nil, fmt.Errorf("graph: %d original vertices for %d transformed vertices", len(f.OriginalTo), f.V)
+	}
+	for i, start := range f.OriginalTo {
+		if start < 0 || start >= f.V {
+			return nil, fmt.Errorf("graph: original vertex %d maps to %d, out of range", i, start)
+		}
+	}
+
+	for x, orig := range f.NewToOrigin {
+		if orig < 0 || orig >= len(f.OriginalTo) {
+			return nil, fmt.Errorf("graph: transformed vertex %d belongs to %d, out of range [0,%d)", x, orig, len(f.OriginalTo))
+		}
+	}
+
+	return &TransformedGraph{
+		G:           &Graph{V: f.V, Adj: f.Adj},
+		OriginalTo:  f.OriginalTo,
+		NewToOrigin: f.NewToOrigin,
+	}, nil
+}
@@ -0,0 +1,83 @@
+package graph_test
+
+import (
+	"bytes"
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/graph"
+	"github.com/phr3nzy/duan-sssp/sssp"
+)
+
+func TestTransformedRoundTrip(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	g := graph.NewGraph(300)
+	for i := 0; i < 900; i++ {
+		g.AddEdge(rng.Intn(300), rng.Intn(300), rng.Float64()*100)
+	}
+	fresh := g.ToConstantDegree()
+	var buf bytes.Buffer
+	if err := fresh.Save(&buf); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	loaded, err := graph.LoadTransformed(&buf)
+	if err != nil {
+		t.Fatalf("LoadTransformed: %v", err)
+	}
+
+	if loaded.G.V != fresh.G.V || !slices.Equal(loaded.OriginalTo, fresh.OriginalTo) ||
+		!slices.Equal(loaded.NewToOrigin, fresh.NewToOrigin) {
+		t.Fatal("loaded mappings differ from the fresh transform")
+	}
+
+	for _, src := range []int{0, 42, 299} {
+		want := fresh.MapDistances(sssp.NewSolver(fresh.G).Run(fresh.OriginalTo[src]))
+		got := loaded.MapDistances(sssp.NewSolver(loaded.G).Run(loaded.OriginalTo[src]))
+		if !slices.Equal(got, want) {
+			t.Errorf("source %d: distances on loaded graph differ", src)
+		}
+	}
+}
+
+func TestLoadTransformedRejectsGarbage(t *testing.T) {
+	if _, err := graph.LoadTransformed(bytes.NewReader([]byte("not a graph"))); err == nil {
+		t.Error("expected error")
+	}
+}
+
+// TestLoadTransformedRejectsCorruptMappings saves transforms whose mappings
+// were damaged after the fact and expects LoadTransformed to refuse them.
+func TestLoadTransformedRejectsCorruptMappings(t *testing.T) {
+	g := graph.NewGraph(20)
+	for u := 0; u < 20; u++ {
+		g.AddEdge(u, (u+1)%20, 1)
+		g.AddEdge(u, (u+7)%20, 2)
+	}
+
+	cases := map[string]func(tg *graph.TransformedGraph){
+		"truncated OriginalTo": func(tg *graph.TransformedGraph) {
+			tg.OriginalTo = tg.OriginalTo[:10]
+		},
+		"NewToOrigin past the original vertices": func(tg *graph.TransformedGraph) {
+			tg.NewToOrigin[3] = len(tg.OriginalTo)
+		},
+		"negative NewToOrigin": func(tg *graph.TransformedGraph) {
+			tg.NewToOrigin[0] = -1
+		},
+		"more original vertices than nodes": func(tg *graph.TransformedGraph) {
+			tg.OriginalTo = append(tg.OriginalTo, make([]int, tg.G.V)...)
+		},
+	}
+	for name, corrupt := range cases {
+		tg := g.ToConstantDegree()
+		corrupt(tg)
+		var buf bytes.Buffer
+		if err := tg.Save(&buf); err != nil {
+			t.Fatalf("%s: Save: %v", name, err)
+		}
+		if _, err := graph.LoadTransformed(&buf); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
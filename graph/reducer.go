@@ -0,0 +1,181 @@
+package graph
+
+// DegreeReducer turns a graph into one of constant degree whose distances,
+// mapped back through the returned TransformedGraph, equal the original's.
+type DegreeReducer interface {
+	Reduce(g *Graph) *TransformedGraph
+}
+
+// CycleReducer replaces each vertex by a zero-weight cycle with one node per
+// incident edge. It is the gadget used by ToConstantDegree.
+type CycleReducer struct{}
+
+// Reduce replaces each vertex v by a cycle of nodes, one for each edge.
+func (CycleReducer) Reduce(g *Graph) *TransformedGraph {
+	// 1. Calculate size of new graph
+	// Each original vertex v needs degree(v) + constant auxiliary nodes.
+	// Simple strategy:
+	// For vertex v with In-degree I and Out-degree O:
+	// Create a "hub" cycle of size max(1, I+O).
+
+	// Faster Approach:
+	// Simply split node u into u_in and u_out is not enough for constant degree.
+	// We must chain edges.
+
+	// Implementation of [Fre83] style transformation:
+	// For each node u, create a chain/cycle of auxiliary nodes.
+	// Total new nodes approx = 2*|E| + |V|.
+
+	// We need to group edges.
+	// Construct simplified expansion:
+	// For each node u:
+	//   Create a "chain" of nodes u_0, u_1, ... u_k where k = out_degree.
+	//   Edge (u,v) becomes (u_i, v_0) with weight w.
+	//   Chain edges (u_i, u_{i+1}) have weight 0.
+	//   Incoming edges?
+	//   The paper says: "Substitute each vertex v with a cycle... For every neighbor w there is a vertex x_vw".
+
+	// Let's use a simpler gadget:
+	// Every original node u becomes a cycle of k nodes, where k = InDegree(u) + OutDegree(u).
+	// If k=0, just 1 node.
+
+	inDegree := g.InDegrees()
+
+	starts := make([]int, g.V)
+	sizes := make([]int, g.V)
+
+	currentID := 0
+	for u := 0; u < g.V; u++ {
+		starts[u] = currentID
+		sz := len(g.Adj[u]) + inDegree[u]
+		if sz == 0 {
+			sz = 1
+		}
+		sizes[u] = sz
+		currentID += sz
+	}
+
+	newG := NewGraph(currentID)
+	newToOrigin := make([]int, currentID)
+
+	// Build Cycles and internal mappings
+	// Map (u, v) edge to specific index in u's cycle (outgoing) and v's cycle (incoming)
+
+	// We need to assign specific "slots" in the cycle for each edge.
+	// slots[u] tracks next available slot for node u.
+	slots := make([]int, g.V)
+
+	// Create zero-weight cycles/chains
+	for u := 0; u < g.V; u++ {
+		start := starts[u]
+		sz := sizes[u]
+		for i := 0; i < sz; i++ {
+			curr := start + i
+			next := start + (i+1)%sz
+			newG.AddEdge(curr, next, 0)
+			newToOrigin[curr] = u
+		}
+	}
+
+	// Add real edges
+	for u := 0; u < g.V; u++ {
+		for _, e := range g.Adj[u] {
+			v := e.To
+			w := e.Weight
+
+			// u's slot for this outgoing edge
+			uSlot := slots[u]
+			slots[u]++
+			uNode := starts[u] + uSlot
+
+			// v's slot for this incoming edge
+			vSlot := slots[v]
+			slots[v]++
+			vNode := starts[v] + vSlot
+
+			newG.AddEdge(uNode, vNode, w)
+		}
+	}
+
+	return &TransformedGraph{
+		G:           newG,
+		OriginalTo:  starts,
+		NewToOrigin: newToOrigin,
+	}
+}
+
+// SplitReducer replaces each vertex u by a center node with a balanced
+// binary out-tree fanning out to one leaf per outgoing edge and a binary
+// in-tree collecting one leaf per incoming edge, all with zero-weight edges.
+// Every node has total degree at most four.
+type SplitReducer struct{}
+
+// Reduce applies the split gadget. OriginalTo maps each vertex to its center.
+func (SplitReducer) Reduce(g *Graph) *TransformedGraph {
+	inDegree := g.InDegrees()
+
+	// Layout per vertex: center, then out-tree, then in-tree. A tree over k
+	// leaves has 2k-1 nodes stored heap-style with leaves at k-1..2k-2.
+	treeSize := func(k int) int {
+		if k == 0 {
+			return 0
+		}
+		return 2*k - 1
+	}
+	centers := make([]int, g.V)
+	outRoot := make([]int, g.V)
+	inRoot := make([]int, g.V)
+	n := 0
+	for u := 0; u < g.V; u++ {
+		centers[u] = n
+		outRoot[u] = n + 1
+		inRoot[u] = outRoot[u] + treeSize(len(g.Adj[u]))
+		n = inRoot[u] + treeSize(inDegree[u])
+	}
+
+	newG := NewGraph(n)
+	newToOrigin := make([]int, n)
+	for u := 0; u < g.V; u++ {
+		end := n
+		if u+1 < g.V {
+			end = centers[u+1]
+		}
+		for x := centers[u]; x < end; x++ {
+			newToOrigin[x] = u
+		}
+
+		if k := len(g.Adj[u]); k > 0 {
+			newG.AddEdge(centers[u], outRoot[u], 0)
+			for i := 0; 2*i+1 < treeSize(k); i++ {
+				newG.AddEdge(outRoot[u]+i, outRoot[u]+2*i+1, 0)
+				newG.AddEdge(outRoot[u]+i, outRoot[u]+2*i+2, 0)
+			}
+		}
+		if k := inDegree[u]; k > 0 {
+			newG.AddEdge(inRoot[u], centers[u], 0)
+			for i := 0; 2*i+1 < treeSize(k); i++ {
+				newG.AddEdge(inRoot[u]+2*i+1, inRoot[u]+i, 0)
+				newG.AddEdge(inRoot[u]+2*i+2, inRoot[u]+i, 0)
+			}
+		}
+	}
+
+	// Connect the i-th out-leaf of u to the next free in-leaf of v
+	inUsed := make([]int, g.V)
+	for u := 0; u < g.V; u++ {
+		k := len(g.Adj[u])
+		for i, e := range g.Adj[u] {
+			v := e.To
+			from := outRoot[u] + k - 1 + i
+			to := inRoot[v] + inDegree[v] - 1 + inUsed[v]
+			inUsed[v]++
+			newG.AddEdge(from, to, e.Weight)
+		}
+	}
+
+	return &TransformedGraph{
+		G:           newG,
+		OriginalTo:  centers,
+		NewToOrigin: newToOrigin,
+	}
+}
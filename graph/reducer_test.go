@@ -0,0 +1,95 @@
+package graph_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/graph"
+	"github.com/phr3nzy/duan-sssp/sssp"
+)
+
+func TestReducersPreserveDistances(t *testing.T) {
+	reducers := map[string]graph.DegreeReducer{
+		"cycle": graph.CycleReducer{},
+		"split": graph.SplitReducer{},
+	}
+
+	for seed := int64(0); seed < 20; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		n := 1 + rng.Intn(300)
+		g := graph.NewGraph(n)
+		for i := 0; i < n*(1+rng.Intn(5)); i++ {
+			g.AddEdge(rng.Intn(n), rng.Intn(n), float64(rng.Intn(20)))
+		}
+		source := rng.Intn(n)
+		want := sssp.Dijkstra(g, source)
+
+		for name, r := range reducers {
+			tg := r.Reduce(g)
+			got := tg.MapDistances(sssp.NewSolver(tg.G).Run(tg.OriginalTo[source]))
+			for v := range want {
+				if math.Abs(got[v]-want[v]) > 1e-9 {
+					t.Fatalf("%s, seed %d: dist[%d] = %v, want %v", name, seed, v, got[v], want[v])
+				}
+			}
+			for x, u := range tg.NewToOrigin {
+				if u < 0 || u >= n {
+					t.Fatalf("%s: node %d maps to invalid vertex %d", name, x, u)
+				}
+			}
+		}
+	}
+}
+
+func TestSplitReducerConstantDegree(t *testing.T) {
+	g := graph.NewGraph(50)
+	for v := 1; v < 50; v++ {
+		g.AddEdge(0, v, 1) // high out-degree hub
+		g.AddEdge(v, 0, 1) // and high in-degree
+	}
+
+	tg := graph.SplitReducer{}.Reduce(g)
+	in := make([]int, tg.G.V)
+	for u := range tg.G.Adj {
+		for _, e := range tg.G.Adj[u] {
+			in[e.To]++
+		}
+	}
+	for x := range tg.G.Adj {
+		if deg := len(tg.G.Adj[x]) + in[x]; deg > 4 {
+			t.Errorf("node %d has degree %d", x, deg)
+		}
+	}
+}
+
+func TestSortAdjacencyPreservesDistances(t *testing.T) {
+	for seed := int64(0); seed < 10; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		n := 2 + rng.Intn(300)
+		g := graph.NewGraph(n)
+		for i := 0; i < n*(1+rng.Intn(5)); i++ {
+			g.AddEdge(rng.Intn(n), rng.Intn(n), float64(rng.Intn(20)))
+		}
+		source := rng.Intn(n)
+
+		tg := g.ToConstantDegree()
+		want := tg.MapDistances(sssp.NewSolver(tg.G).Run(tg.OriginalTo[source]))
+
+		tg.SortAdjacency()
+		for x, adj := range tg.G.Adj {
+			for i := 1; i < len(adj); i++ {
+				if adj[i-1].To > adj[i].To {
+					t.Fatalf("seed %d: node %d edges not sorted: %+v", seed, x, adj)
+				}
+			}
+		}
+
+		got := tg.MapDistances(sssp.NewSolver(tg.G).Run(tg.OriginalTo[source]))
+		for v := range want {
+			if got[v] != want[v] {
+				t.Fatalf("seed %d: dist[%d] = %v after sorting, want %v", seed, v, got[v], want[v])
+			}
+		}
+	}
+}
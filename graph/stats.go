@@ -0,0 +1,33 @@
+package graph
+
+// Stats summarizes the size and degree distribution of a graph.
+type Stats struct {
+	Vertices  int
+	Edges     int
+	AvgDegree float64 // Mean out-degree
+	MaxDegree int
+	MinDegree int
+	Density   float64 // Edges / (V*(V-1)), the fraction of possible directed edges
+}
+
+// Stats computes vertex/edge counts, out-degree extremes and density.
+func (g *Graph) Stats() Stats {
+	st := Stats{Vertices: g.V}
+	if g.V == 0 {
+		return st
+	}
+
+	st.MinDegree = len(g.Adj[0])
+	for u := 0; u < g.V; u++ {
+		deg := len(g.Adj[u])
+		st.Edges += deg
+		st.MaxDegree = max(st.MaxDegree, deg)
+		st.MinDegree = min(st.MinDegree, deg)
+	}
+
+	st.AvgDegree = float64(st.Edges) / float64(g.V)
+	if g.V > 1 {
+		st.Density = float64(st.Edges) / float64(g.V*(g.V-1))
+	}
+	return st
+}
@@ -0,0 +1,29 @@
+package graph
+
+import "testing"
+
+func TestStats(t *testing.T) {
+	g := NewGraph(5)
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(0, 2, 1)
+	g.AddEdge(0, 3, 1)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 0, 1)
+	g.AddEdge(3, 4, 1)
+
+	want := Stats{
+		Vertices:  5,
+		Edges:     6,
+		AvgDegree: 1.2,
+		MaxDegree: 3,
+		MinDegree: 0,
+		Density:   0.3,
+	}
+	if got := g.Stats(); got != want {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+
+	if got := NewGraph(0).Stats(); got != (Stats{}) {
+		t.Errorf("empty graph Stats() = %+v", got)
+	}
+}
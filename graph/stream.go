@@ -0,0 +1,87 @@
+package graph
+
+import (
+	"fmt"
+	"io"
+)
+
+// StreamTransform builds the CycleReducer transform of the n-vertex graph
+// whose "u,v,weight" CSV rows (no header) r holds, without materializing the
+// original graph. The first pass counts degrees to lay out the cycles; r is
+// then rewound and the second pass adds the real edges.
+//
+// Cycle slots are handed out in row order, so the result equals
+// ToConstantDegree of LoadEdgeListCSV's graph when rows are sorted by source.
+// Otherwise edges may attach at different positions of a cycle; since cycle
+// edges weigh zero, distances are the same.
+func StreamTransform(r io.ReadSeeker, n int) (*TransformedGraph, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("graph: negative vertex count %d", n)
+	}
+	start, err := r.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return nil, fmt.Errorf("graph: StreamTransform: %w", err)
+	}
+
+	outDegree := make([]int, n)
+	inDegree := make([]int, n)
+	err = readEdgeCSV(r, false, func(line int, e EdgeTriple) error {
+		if e.From >= n || e.To >= n {
+			return fmt.Errorf("graph: line %d: edge %d->%d out of range [0,%d)", line, e.From, e.To, n)
+		}
+		outDegree[e.From]++
+		inDegree[e.To]++
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	total, err := transformedVertices(outDegree, inDegree, MaxTransformedVertices)
+	if err != nil {
+		return nil, err
+	}
+
+	// Same layout as CycleReducer: a cycle of max(1, out+in) nodes per vertex
+	size := func(u int) int { return max(1, outDegree[u]+inDegree[u]) }
+	starts := make([]int, n)
+	newG := NewGraph(total)
+	newToOrigin := make([]int, total)
+	id := 0
+	for u := 0; u < n; u++ {
+		starts[u] = id
+		sz := size(u)
+		for i := 0; i < sz; i++ {
+			newG.AddEdge(id+i, id+(i+1)%sz, 0)
+			newToOrigin[id+i] = u
+		}
+		id += sz
+	}
+
+	if _, err := r.Seek(start, io.SeekStart); err != nil {
+		return nil, fmt.Errorf("graph: StreamTransform: %w", err)
+	}
+	slots := make([]int, n) // next free slot in each vertex's cycle
+	err = readEdgeCSV(r, false, func(line int, e EdgeTriple) error {
+		if e.From >= n || e.To >= n {
+			return fmt.Errorf("graph: line %d: input changed between passes", line)
+		}
+		uNode := starts[e.From] + slots[e.From]
+		slots[e.From]++
+		vNode := starts[e.To] + slots[e.To]
+		slots[e.To]++
+		if slots[e.From] > size(e.From) || slots[e.To] > size(e.To) {
+			return fmt.Errorf("graph: line %d: input changed between passes", line)
+		}
+		newG.AddEdge(uNode, vNode, e.Weight)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TransformedGraph{
+		G:           newG,
+		OriginalTo:  starts,
+		NewToOrigin: newToOrigin,
+	}, nil
+}
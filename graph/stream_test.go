@@ -0,0 +1,87 @@
+package graph_test
+
+import (
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/graph"
+	"github.com/phr3nzy/duan-sssp/sssp"
+)
+
+func randomEdgeCSV(rng *rand.Rand, n, m int, sorted bool) string {
+	type row struct {
+		u, v int
+		w    float64
+	}
+	rows := make([]row, m)
+	for i := range rows {
+		rows[i] = row{rng.Intn(n), rng.Intn(n), float64(rng.Intn(50))}
+	}
+	rows[0].u, rows[0].v = n-1, n-1 // fix the vertex count, with a self-loop
+	if sorted {
+		sort.SliceStable(rows, func(i, j int) bool { return rows[i].u < rows[j].u })
+	}
+
+	var b strings.Builder
+	for _, r := range rows {
+		fmt.Fprintf(&b, "%d,%d,%g\n", r.u, r.v, r.w)
+	}
+	return b.String()
+}
+
+func TestStreamTransformMatchesToConstantDegree(t *testing.T) {
+	for seed := int64(0); seed < 10; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		n := 2 + rng.Intn(200)
+
+		for _, sorted := range []bool{true, false} {
+			in := randomEdgeCSV(rng, n, 4*n, sorted)
+			g, err := graph.LoadEdgeListCSV(strings.NewReader(in), false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := g.ToConstantDegree()
+
+			got, err := graph.StreamTransform(strings.NewReader(in), g.V)
+			if err != nil {
+				t.Fatalf("seed %d: %v", seed, err)
+			}
+			if !slices.Equal(got.OriginalTo, want.OriginalTo) || !slices.Equal(got.NewToOrigin, want.NewToOrigin) {
+				t.Fatalf("seed %d, sorted %v: vertex mappings differ", seed, sorted)
+			}
+
+			if sorted {
+				if diff := graph.Diff(want.G, got.G); diff != nil {
+					t.Fatalf("seed %d: sorted input differs from ToConstantDegree: %v", seed, diff[:min(3, len(diff))])
+				}
+				continue
+			}
+
+			source := rng.Intn(n)
+			wantDist := want.MapDistances(sssp.NewSolver(want.G).Run(want.OriginalTo[source]))
+			gotDist := got.MapDistances(sssp.NewSolver(got.G).Run(got.OriginalTo[source]))
+			if !slices.Equal(gotDist, wantDist) {
+				t.Fatalf("seed %d: unsorted input gives different distances", seed)
+			}
+		}
+	}
+}
+
+func TestStreamTransformErrors(t *testing.T) {
+	for name, c := range map[string]struct {
+		in string
+		n  int
+	}{
+		"out of range": {"0,1,1\n1,5,1\n", 3},
+		"bad weight":   {"0,1,x\n", 2},
+		"negative n":   {"", -1},
+	} {
+		if _, err := graph.StreamTransform(strings.NewReader(c.in), c.n); err == nil {
+			t.Errorf("%s: no error", name)
+		}
+	}
+}
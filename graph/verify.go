@@ -0,0 +1,80 @@
+package graph
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+)
+
+// VerifyTransform checks that ToConstantDegree preserves distances from
+// source: it runs Dijkstra on g and on the transformed graph from source's
+// start node, maps the latter back with MapDistances and compares the two. It
+// returns an error naming the first vertex whose distances differ by more
+// than a relative 1e-9, or whose reachability differs, and nil otherwise.
+func VerifyTransform(g *Graph, source int) error {
+	if source < 0 || source >= g.V {
+		return fmt.Errorf("graph: source %d out of range [0,%d)", source, g.V)
+	}
+	tg, err := g.ToConstantDegreeE()
+	if err != nil {
+		return err
+	}
+
+	want := dijkstra(g, source)
+	got := tg.MapDistances(dijkstra(tg.G, tg.StartNode(source)))
+	for v := range want {
+		w, d := want[v], got[v]
+		if math.IsInf(w, 1) || d >= math.MaxFloat64 {
+			if !math.IsInf(w, 1) || d < math.MaxFloat64 {
+				return fmt.Errorf("graph: vertex %d: transformed distance %v, original %v", v, d, w)
+			}
+			continue
+		}
+		if math.Abs(d-w) > 1e-9*max(1, math.Abs(w)) {
+			return fmt.Errorf("graph: vertex %d: transformed distance %v, original %v", v, d, w)
+		}
+	}
+	return nil
+}
+
+// dijkstra returns distances from source in g, +Inf where unreachable. It is
+// a plain reference kept here so that graph does not depend on sssp.
+func dijkstra(g *Graph, source int) []float64 {
+	dist := make([]float64, g.V)
+	for i := range dist {
+		dist[i] = math.Inf(1)
+	}
+	dist[source] = 0
+	h := &distHeap{{source, 0}}
+	for h.Len() > 0 {
+		top := heap.Pop(h).(distEntry)
+		if top.d > dist[top.v] {
+			continue
+		}
+		for _, e := range g.Adj[top.v] {
+			if nd := top.d + e.Weight; nd < dist[e.To] {
+				dist[e.To] = nd
+				heap.Push(h, distEntry{e.To, nd})
+			}
+		}
+	}
+	return dist
+}
+
+type distEntry struct {
+	v int
+	d float64
+}
+
+type distHeap []distEntry
+
+func (h distHeap) Len() int            { return len(h) }
+func (h distHeap) Less(i, j int) bool  { return h[i].d < h[j].d }
+func (h distHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *distHeap) Push(x interface{}) { *h = append(*h, x.(distEntry)) }
+func (h *distHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
@@ -0,0 +1,30 @@
+package graph
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestVerifyTransform(t *testing.T) {
+	for seed := int64(0); seed < 30; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		n := 1 + rng.Intn(150)
+		g := NewGraph(n)
+		for i := 0; i < rng.Intn(4*n); i++ {
+			// Some zero weights, so the cycles' zero edges tie with real ones
+			g.AddEdge(rng.Intn(n), rng.Intn(n), float64(rng.Intn(4))*rng.Float64())
+		}
+		if err := VerifyTransform(g, rng.Intn(n)); err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+	}
+}
+
+func TestVerifyTransformSourceRange(t *testing.T) {
+	g := NewGraph(3)
+	for _, source := range []int{-1, 3} {
+		if err := VerifyTransform(g, source); err == nil {
+			t.Errorf("source %d: expected error", source)
+		}
+	}
+}
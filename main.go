@@ -1,30 +1,28 @@
 package main
 
 import (
+	"flag"
 	"fmt"
-	"math/rand"
 	"time"
 
-	"github.com/phr3nzy/duan-sssp/graph"
+	"github.com/phr3nzy/duan-sssp/gen"
 	"github.com/phr3nzy/duan-sssp/sssp"
 )
 
 func main() {
+	vertices := flag.Int("vertices", 10000, "Number of vertices")
+	edgeFactor := flag.Int("edge-factor", 3, "Edges = vertices * edge-factor")
+	seed := flag.Int64("seed", 1, "Random seed for graph generation")
+	flag.Parse()
+
 	fmt.Println("Initializing High-Performance SSSP (Duan et al., 2025)...")
 
 	// 1. Generate a Sparse Random Graph
-	V := 10000
-	E := V * 3
-	fmt.Printf("Generating graph V=%d, E=%d...\n", V, E)
-
-	g := graph.NewGraph(V)
-	rand.Seed(time.Now().UnixNano())
-	for i := 0; i < E; i++ {
-		u := rand.Intn(V)
-		v := rand.Intn(V)
-		w := rand.Float64() * 100.0
-		g.AddEdge(u, v, w)
-	}
+	V := *vertices
+	E := V * *edgeFactor
+	fmt.Printf("Generating graph V=%d, E=%d (seed %d)...\n", V, E, *seed)
+
+	g := gen.Random(V, E, *seed)
 
 	// 2. Transform (Critical Step)
 	fmt.Println("Transforming to Constant Degree Graph...")
@@ -43,6 +41,11 @@ func main() {
 
 	// 4. Verification (Spot Check)
 	mapped := tg.MapDistances(rawDist)
-	fmt.Printf("Distance to node 10: %f\n", mapped[10])
+	target := min(10, V-1)
+	if mapped[target] == sssp.Infinity {
+		fmt.Printf("Node %d is unreachable\n", target)
+	} else {
+		fmt.Printf("Distance to node %d: %f\n", target, mapped[target])
+	}
 	fmt.Println("Done.")
 }
@@ -0,0 +1,83 @@
+package sssp
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/phr3nzy/duan-sssp/graph"
+)
+
+// AllPairs returns the n×n distance matrix of g, where row u holds the
+// distances from u. The graph is transformed once and the sources are split
+// across workers goroutines, each with its own solver.
+func AllPairs(g *graph.Graph, workers int) [][]float64 {
+	tg := g.ToConstantDegree()
+	sources := make([]int, g.V)
+	for u := range sources {
+		sources[u] = u
+	}
+	return runSources(tg, sources, workers)
+}
+
+// MultiSourceParallel solves from each original vertex in sources and returns
+// the mapped distances keyed by source. At most workers goroutines run, each
+// with its own solver; duplicate sources are solved once. Like RunMulti, it
+// returns an error before solving anything if sources is empty or holds a
+// vertex outside the original graph.
+func MultiSourceParallel(tg *graph.TransformedGraph, sources []int, workers int) (map[int][]float64, error) {
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("sssp: no sources")
+	}
+	for _, src := range sources {
+		if src < 0 || src >= len(tg.OriginalTo) {
+			return nil, fmt.Errorf("sssp: source %d out of range [0,%d)", src, len(tg.OriginalTo))
+		}
+	}
+
+	seen := make(map[int]bool, len(sources))
+	unique := make([]int, 0, len(sources))
+	for _, src := range sources {
+		if !seen[src] {
+			seen[src] = true
+			unique = append(unique, src)
+		}
+	}
+
+	dist := runSources(tg, unique, workers)
+	res := make(map[int][]float64, len(unique))
+	for i, src := range unique {
+		res[src] = dist[i]
+	}
+	return res, nil
+}
+
+// runSources returns the mapped distances from each original vertex in
+// sources, in the same order, using workers goroutines with a solver each.
+func runSources(tg *graph.TransformedGraph, sources []int, workers int) [][]float64 {
+	if workers < 1 {
+		workers = 1
+	}
+
+	dist := make([][]float64, len(sources))
+	jobs := make(chan int)
+
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			solver := NewSolver(tg.G)
+			for i := range jobs {
+				dist[i] = tg.MapDistances(solver.Run(tg.OriginalTo[sources[i]]))
+			}
+		}()
+	}
+
+	for i := range sources {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+
+	return dist
+}
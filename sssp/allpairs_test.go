@@ -0,0 +1,96 @@
+package sssp
+
+import (
+	"math"
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/gen"
+)
+
+func TestAllPairs(t *testing.T) {
+	g := gen.Random(60, 240, 1)
+	got := AllPairs(g, 4)
+
+	// Floyd-Warshall reference
+	want := make([][]float64, g.V)
+	for u := range want {
+		want[u] = make([]float64, g.V)
+		for v := range want[u] {
+			want[u][v] = Infinity
+		}
+		want[u][u] = 0
+		for _, e := range g.Adj[u] {
+			want[u][e.To] = math.Min(want[u][e.To], e.Weight)
+		}
+	}
+	for k := 0; k < g.V; k++ {
+		for i := 0; i < g.V; i++ {
+			if want[i][k] == Infinity {
+				continue
+			}
+			for j := 0; j < g.V; j++ {
+				if want[k][j] != Infinity && want[i][k]+want[k][j] < want[i][j] {
+					want[i][j] = want[i][k] + want[k][j]
+				}
+			}
+		}
+	}
+
+	for u := range want {
+		for v := range want[u] {
+			if math.Abs(got[u][v]-want[u][v]) > 1e-9 {
+				t.Fatalf("dist[%d][%d] = %v, want %v", u, v, got[u][v], want[u][v])
+			}
+		}
+	}
+}
+
+func TestAllPairsRows(t *testing.T) {
+	g := gen.Random(500, 1500, 1)
+	got := AllPairs(g, 3)
+
+	for _, u := range []int{0, 17, 250, 499} {
+		want := Dijkstra(g, u)
+		for v := range want {
+			if math.Abs(got[u][v]-want[v]) > 1e-9 {
+				t.Fatalf("row %d: dist[%d] = %v, want %v", u, v, got[u][v], want[v])
+			}
+		}
+	}
+}
+
+// TestMultiSourceParallel is meant to run under -race as well.
+func TestMultiSourceParallel(t *testing.T) {
+	g := gen.Random(400, 1600, 9)
+	tg := g.ToConstantDegree()
+	sources := []int{0, 17, 399, 17, 250, 3, 120, 64}
+
+	got, err := MultiSourceParallel(tg, sources, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 7 {
+		t.Fatalf("got %d entries, want 7 distinct sources", len(got))
+	}
+	for _, src := range sources {
+		want := ShortestPaths(g, src)
+		dist, ok := got[src]
+		if !ok {
+			t.Fatalf("missing source %d", src)
+		}
+		for v := range want {
+			if dist[v] != want[v] {
+				t.Fatalf("source %d: dist[%d] = %v, want %v", src, v, dist[v], want[v])
+			}
+		}
+	}
+}
+
+func TestMultiSourceParallelInvalidSources(t *testing.T) {
+	tg := gen.Random(50, 150, 1).ToConstantDegree()
+	for _, sources := range [][]int{nil, {0, 50}, {-1}} {
+		if got, err := MultiSourceParallel(tg, sources, 2); err == nil {
+			t.Errorf("sources %v: got %d results, want an error", sources, len(got))
+		}
+	}
+}
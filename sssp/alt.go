@@ -0,0 +1,63 @@
+package sssp
+
+import "github.com/phr3nzy/duan-sssp/graph"
+
+// ALT answers point-to-point queries with A*, using precomputed landmark
+// distances and the triangle inequality as an admissible heuristic.
+type ALT struct {
+	g    *graph.Graph
+	from [][]float64 // from[i][v] = d(landmark i, v)
+	to   [][]float64 // to[i][v] = d(v, landmark i)
+}
+
+// NewALT precomputes distances from and to each landmark with the solver.
+func NewALT(g *graph.Graph, landmarks []int) *ALT {
+	fwd := g.ToConstantDegree()
+	bwd := g.Reverse().ToConstantDegree()
+	fwdSolver := NewSolver(fwd.G)
+	bwdSolver := NewSolver(bwd.G)
+
+	a := &ALT{
+		g:    g,
+		from: make([][]float64, len(landmarks)),
+		to:   make([][]float64, len(landmarks)),
+	}
+	for i, l := range landmarks {
+		a.from[i] = fwd.MapDistances(fwdSolver.Run(fwd.OriginalTo[l]))
+		a.to[i] = bwd.MapDistances(bwdSolver.Run(bwd.OriginalTo[l]))
+	}
+	return a
+}
+
+// lowerBound returns a lower bound on d(v, target).
+func (a *ALT) lowerBound(v, target int) float64 {
+	h := 0.0
+	for i := range a.from {
+		// d(L,t) <= d(L,v) + d(v,t)
+		if !isUnreachable(a.from[i][v]) && !isUnreachable(a.from[i][target]) {
+			h = max(h, a.from[i][target]-a.from[i][v])
+		}
+		// d(v,L) <= d(v,t) + d(t,L)
+		if !isUnreachable(a.to[i][v]) && !isUnreachable(a.to[i][target]) {
+			h = max(h, a.to[i][v]-a.to[i][target])
+		}
+	}
+	return h
+}
+
+// Query returns the distance from source to target and a shortest path, or
+// Infinity and nil if target is unreachable.
+func (a *ALT) Query(source, target int) (float64, []int) {
+	h := make([]float64, a.g.V)
+	for i := range h {
+		h[i] = -1
+	}
+
+	bound := func(v int) float64 {
+		if h[v] < 0 {
+			h[v] = a.lowerBound(v, target)
+		}
+		return h[v]
+	}
+	return astar(a.g, source, target, bound)
+}
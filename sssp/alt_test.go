@@ -0,0 +1,37 @@
+package sssp
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/gen"
+)
+
+func TestALTMatchesDijkstra(t *testing.T) {
+	g := gen.Random(400, 1600, 1)
+	alt := NewALT(g, []int{0, 100, 200, 300})
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 200; i++ {
+		source, target := rng.Intn(g.V), rng.Intn(g.V)
+		want := Dijkstra(g, source)[target]
+		got, path := alt.Query(source, target)
+
+		if math.Abs(got-want) > 1e-9 && !(got == Infinity && want == Infinity) {
+			t.Fatalf("%d->%d: ALT %v, Dijkstra %v", source, target, got, want)
+		}
+		if want == Infinity {
+			if path != nil {
+				t.Errorf("%d->%d: unreachable but got path %v", source, target, path)
+			}
+			continue
+		}
+		if path[0] != source || path[len(path)-1] != target {
+			t.Fatalf("%d->%d: path %v has wrong endpoints", source, target, path)
+		}
+		if cost := pathCost(g, path); math.Abs(cost-got) > 1e-9 {
+			t.Errorf("%d->%d: path cost %v, distance %v", source, target, cost, got)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package sssp
+
+import (
+	"container/heap"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/phr3nzy/duan-sssp/graph"
+)
+
+// Metric selects how AStarGeo measures the straight-line distance between
+// two coordinates.
+type Metric int
+
+const (
+	// Euclidean is the straight-line distance between (x, y) points.
+	Euclidean Metric = iota
+	// Manhattan is |dx| + |dy| between (x, y) points.
+	Manhattan
+	// Haversine is the great-circle distance in kilometres between
+	// (latitude, longitude) points given in degrees.
+	Haversine
+	// Chebyshev is max(|dx|, |dy|) between (x, y) points, the admissible
+	// choice for 8-connected grids from graph.NewGrid.
+	Chebyshev
+)
+
+// earthRadiusKm is the mean Earth radius used by Haversine.
+const earthRadiusKm = 6371.0
+
+// distance returns the metric's distance between a and b.
+func (m Metric) distance(a, b [2]float64) float64 {
+	switch m {
+	case Manhattan:
+		return math.Abs(a[0]-b[0]) + math.Abs(a[1]-b[1])
+	case Chebyshev:
+		return math.Max(math.Abs(a[0]-b[0]), math.Abs(a[1]-b[1]))
+	case Haversine:
+		lat1, lat2 := a[0]*math.Pi/180, b[0]*math.Pi/180
+		dLat := lat2 - lat1
+		dLon := (b[1] - a[1]) * math.Pi / 180
+		h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+			math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+		return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+	default:
+		return math.Hypot(a[0]-b[0], a[1]-b[1])
+	}
+}
+
+// AStarGeo returns the distance from source to target and a shortest path,
+// using the metric distance between coords as the A* heuristic. Each edge
+// weight must be at least the metric distance between its endpoints (in the
+// same unit) for the result to be exact. With nil coords it behaves like
+// Dijkstra stopped at target. An unreachable target yields Infinity and a nil
+// path.
+func AStarGeo(g *graph.Graph, coords [][2]float64, source, target int, metric Metric) (float64, []int, error) {
+	if source < 0 || source >= g.V || target < 0 || target >= g.V {
+		return 0, nil, fmt.Errorf("sssp: source %d or target %d out of range [0,%d)", source, target, g.V)
+	}
+	if metric < Euclidean || metric > Chebyshev {
+		return 0, nil, fmt.Errorf("sssp: unknown metric %d", metric)
+	}
+
+	h := func(int) float64 { return 0 }
+	if coords != nil {
+		if len(coords) != g.V {
+			return 0, nil, fmt.Errorf("sssp: got %d coordinates for %d vertices", len(coords), g.V)
+		}
+		h = func(v int) float64 { return metric.distance(coords[v], coords[target]) }
+	}
+
+	d, path := astar(g, source, target, h)
+	return d, path, nil
+}
+
+// astar runs A* from source to target with heuristic h, which must never
+// overestimate the remaining distance. It returns the distance and a
+// shortest path, or Infinity and nil if target is unreachable.
+func astar(g *graph.Graph, source, target int, h func(v int) float64) (float64, []int) {
+	dist := make([]float64, g.V)
+	pred := make([]int, g.V)
+	for i := range dist {
+		dist[i] = Infinity
+		pred[i] = -1
+	}
+
+	dist[source] = 0
+	pq := &PriorityQueue{}
+	heap.Push(pq, &PQItem{u: source, priority: h(source)})
+
+	for pq.Len() > 0 {
+		item := heap.Pop(pq).(*PQItem)
+		u := item.u
+		if u == target {
+			break
+		}
+
+		// Skip stale entries
+		if item.priority > dist[u]+h(u) {
+			continue
+		}
+
+		for _, edge := range g.Adj[u] {
+			newDist := dist[u] + edge.Weight
+			if newDist < dist[edge.To] {
+				dist[edge.To] = newDist
+				pred[edge.To] = u
+				heap.Push(pq, &PQItem{u: edge.To, priority: newDist + h(edge.To)})
+			}
+		}
+	}
+
+	if isUnreachable(dist[target]) {
+		return Infinity, nil
+	}
+	var path []int
+	for v := target; v != -1; v = pred[v] {
+		path = append(path, v)
+	}
+	slices.Reverse(path)
+	return dist[target], path
+}
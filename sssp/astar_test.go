@@ -0,0 +1,134 @@
+package sssp
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/graph"
+)
+
+// coordGraph places n points at random and connects each to a few random
+// others, with weights stretched 1-2x over the metric distance so that the
+// metric heuristic is admissible.
+func coordGraph(n int, metric Metric, seed int64) (*graph.Graph, [][2]float64) {
+	rng := rand.New(rand.NewSource(seed))
+	coords := make([][2]float64, n)
+	for i := range coords {
+		if metric == Haversine {
+			coords[i] = [2]float64{51 + rng.Float64(), -1 + rng.Float64()*2}
+		} else {
+			coords[i] = [2]float64{rng.Float64() * 100, rng.Float64() * 100}
+		}
+	}
+
+	g := graph.NewGraph(n)
+	for u := 0; u < n; u++ {
+		for i := 0; i < 4; i++ {
+			v := rng.Intn(n)
+			g.AddEdge(u, v, metric.distance(coords[u], coords[v])*(1.01+rng.Float64()))
+		}
+	}
+	return g, coords
+}
+
+func TestAStarGeoMatchesDijkstra(t *testing.T) {
+	for _, metric := range []Metric{Euclidean, Manhattan, Haversine, Chebyshev} {
+		g, coords := coordGraph(500, metric, int64(metric))
+		want := Dijkstra(g, 0)
+
+		for target := 0; target < g.V; target += 37 {
+			for _, c := range [][][2]float64{coords, nil} {
+				d, path, err := AStarGeo(g, c, 0, target, metric)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if math.Abs(d-want[target]) > 1e-9*max(1, want[target]) {
+					t.Fatalf("metric %d, coords %v: dist to %d = %v, want %v", metric, c != nil, target, d, want[target])
+				}
+				if d == Infinity {
+					if path != nil {
+						t.Fatalf("unreachable target %d has path %v", target, path)
+					}
+					continue
+				}
+				if path[0] != 0 || path[len(path)-1] != target {
+					t.Fatalf("path %v does not run from 0 to %d", path, target)
+				}
+			}
+		}
+	}
+}
+
+func TestAStarGeoErrors(t *testing.T) {
+	g, coords := coordGraph(10, Euclidean, 1)
+	if _, _, err := AStarGeo(g, coords[:5], 0, 1, Euclidean); err == nil {
+		t.Error("expected error for short coords")
+	}
+	if _, _, err := AStarGeo(g, coords, 0, 10, Euclidean); err == nil {
+		t.Error("expected error for target out of range")
+	}
+	if _, _, err := AStarGeo(g, coords, 0, 1, Metric(7)); err == nil {
+		t.Error("expected error for unknown metric")
+	}
+}
+
+// TestAStarGeoGrid routes across grids with walls and patches of rough
+// ground, using the grid heuristic for each connectivity.
+func TestAStarGeoGrid(t *testing.T) {
+	const w, h = 12, 9
+	cost := func(x, y int) float64 {
+		switch {
+		case x == 4 && y < 7, x == 8 && y > 1:
+			return math.Inf(1)
+		case (x+y)%5 == 0:
+			return 3
+		}
+		return 1
+	}
+
+	for _, tc := range []struct {
+		diagonal bool
+		metric   Metric
+	}{
+		{false, Manhattan},
+		{true, Chebyshev},
+	} {
+		g, index := graph.NewGrid(w, h, tc.diagonal, cost)
+		coords := make([][2]float64, g.V)
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				coords[index(x, y)] = [2]float64{float64(x), float64(y)}
+			}
+		}
+
+		source := index(0, 0)
+		want := Dijkstra(g, source)
+		for _, target := range []int{index(w-1, h-1), index(5, 0), index(4, 8), index(4, 0)} {
+			d, path, err := AStarGeo(g, coords, source, target, tc.metric)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(d-want[target]) > 1e-9*max(1, want[target]) {
+				t.Fatalf("diagonal %v: dist to %d = %v, want %v", tc.diagonal, target, d, want[target])
+			}
+			if isUnreachable(d) {
+				if cost(target%w, target/w) < math.MaxFloat64 {
+					t.Fatalf("diagonal %v: open cell %d unreachable", tc.diagonal, target)
+				}
+				continue
+			}
+			if path[0] != source || path[len(path)-1] != target {
+				t.Fatalf("diagonal %v: path %v does not run from %d to %d", tc.diagonal, path, source, target)
+			}
+		}
+	}
+}
+
+func TestHaversineDistance(t *testing.T) {
+	// London to Paris is about 344 km
+	d := Haversine.distance([2]float64{51.5074, -0.1278}, [2]float64{48.8566, 2.3522})
+	if math.Abs(d-344) > 2 {
+		t.Errorf("London-Paris = %.1f km, want about 344", d)
+	}
+}
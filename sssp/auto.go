@@ -0,0 +1,51 @@
+package sssp
+
+import "github.com/phr3nzy/duan-sssp/graph"
+
+// Algorithms RecommendAlgorithm chooses between
+const (
+	AlgoBMSSP              = "bmssp"               // ToConstantDegree, then Run
+	AlgoBMSSPUntransformed = "bmssp-untransformed" // RunUntransformed on g itself
+	AlgoDijkstra           = "dijkstra"
+)
+
+// Thresholds for RecommendAlgorithm
+const (
+	// A vertex of ToConstantDegree's output has in+out degree at most 3, so
+	// graphs already this sparse gain nothing from the transform.
+	boundedDegree = 4
+	// Above either of these the transform multiplies the vertex count by
+	// roughly the average degree and plain Dijkstra wins.
+	denseAvgDegree = 32
+	denseFraction  = 0.1
+)
+
+// RecommendAlgorithm picks how to solve g: plain Dijkstra for dense graphs,
+// BMSSP directly when every vertex's in+out degree is already small, and
+// the transformed BMSSP otherwise. The thresholds are rough and may change.
+func RecommendAlgorithm(g *graph.Graph) string {
+	st := g.Stats()
+	if st.AvgDegree > denseAvgDegree || st.Density > denseFraction {
+		return AlgoDijkstra
+	}
+
+	inDegree := g.InDegrees()
+	for u := 0; u < g.V; u++ {
+		if g.OutDegree(u)+inDegree[u] > boundedDegree {
+			return AlgoBMSSP
+		}
+	}
+	return AlgoBMSSPUntransformed
+}
+
+// AutoSolve returns distances from source in g, indexed by g's vertices,
+// using the algorithm RecommendAlgorithm picks.
+func AutoSolve(g *graph.Graph, source int) []float64 {
+	switch RecommendAlgorithm(g) {
+	case AlgoDijkstra:
+		return Dijkstra(g, source)
+	case AlgoBMSSPUntransformed:
+		return NewSolver(g).RunUntransformed(source)
+	}
+	return ShortestPaths(g, source)
+}
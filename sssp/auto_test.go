@@ -0,0 +1,44 @@
+package sssp
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/gen"
+	"github.com/phr3nzy/duan-sssp/graph"
+)
+
+func TestRecommendAlgorithm(t *testing.T) {
+	complete := graph.NewGraph(60)
+	for u := 0; u < 60; u++ {
+		for v := 0; v < 60; v++ {
+			if u != v && (u+v)%7 != 0 { // near-complete
+				complete.AddEdge(u, v, float64(1+(u*v)%13))
+			}
+		}
+	}
+
+	path := graph.NewGraph(500)
+	for v := 0; v+1 < 500; v++ {
+		path.AddEdge(v, v+1, 1)
+		path.AddEdge(v+1, v, 2)
+	}
+
+	tests := []struct {
+		name string
+		g    *graph.Graph
+		want string
+	}{
+		{"sparse random", gen.Random(2000, 6000, 1), AlgoBMSSP},
+		{"near-complete", complete, AlgoDijkstra},
+		{"bidirectional path", path, AlgoBMSSPUntransformed},
+	}
+	for _, tc := range tests {
+		if got := RecommendAlgorithm(tc.g); got != tc.want {
+			t.Errorf("%s: RecommendAlgorithm = %q, want %q", tc.name, got, tc.want)
+		}
+		if got, want := AutoSolve(tc.g, 0), Dijkstra(tc.g, 0); !slices.Equal(got, want) {
+			t.Errorf("%s: AutoSolve distances differ from Dijkstra", tc.name)
+		}
+	}
+}
@@ -0,0 +1,146 @@
+package sssp
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/gen"
+	"github.com/phr3nzy/duan-sssp/graph"
+)
+
+// TestBaseCaseExcludesVerticesAtOrAboveBound builds a star where some leaves
+// land exactly on or just over B. Only the ones strictly below B may be
+// settled, and the others must keep their old distance.
+func TestBaseCaseExcludesVerticesAtOrAboveBound(t *testing.T) {
+	const B = 5.0
+	g := graph.NewGraph(6)
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(0, 2, 4.999)
+	g.AddEdge(0, 3, B)
+	g.AddEdge(0, 4, B+1e-9)
+	g.AddEdge(1, 5, 4.5) // 5.5 via vertex 1
+
+	solver := NewSolver(g)
+	solver.BaseCaseLimit = 10 // large enough that the limit never cuts the search
+	for i := range solver.Dist {
+		solver.Dist[i] = Infinity
+	}
+	solver.Dist[0] = 0
+
+	bound, U := solver.BaseCase(B, []int{0})
+	if bound != B {
+		t.Errorf("bound = %v, want %v", bound, B)
+	}
+
+	inU := make(map[int]bool)
+	for _, u := range U {
+		inU[u] = true
+		if solver.Dist[u] >= B {
+			t.Errorf("vertex %d in U has dist %v >= B", u, solver.Dist[u])
+		}
+	}
+	for _, u := range []int{0, 1, 2} {
+		if !inU[u] {
+			t.Errorf("vertex %d below B missing from U", u)
+		}
+	}
+	for _, u := range []int{3, 4, 5} {
+		if inU[u] {
+			t.Errorf("vertex %d at or above B included in U", u)
+		}
+		if solver.Dist[u] != Infinity {
+			t.Errorf("dist[%d] = %v, want unchanged Infinity", u, solver.Dist[u])
+		}
+	}
+}
+
+// TestBaseCaseSkipsSourcesAtBound checks that a frontier vertex already at B
+// is neither settled nor expanded.
+func TestBaseCaseSkipsSourcesAtBound(t *testing.T) {
+	g := graph.NewGraph(3)
+	g.AddEdge(1, 2, 0)
+
+	solver := NewSolver(g)
+	solver.BaseCaseLimit = 10
+	for i := range solver.Dist {
+		solver.Dist[i] = Infinity
+	}
+	solver.Dist[0] = 1
+	solver.Dist[1] = 2
+
+	_, U := solver.BaseCase(2, []int{0, 1})
+	if len(U) != 1 || U[0] != 0 {
+		t.Errorf("U = %v, want [0]", U)
+	}
+	if solver.Dist[2] != Infinity {
+		t.Errorf("dist[2] = %v, want Infinity", solver.Dist[2])
+	}
+}
+
+func TestBaseCaseLimitKeepsDistances(t *testing.T) {
+	g := gen.Random(2000, 8000, 11)
+	want := Dijkstra(g, 0)
+	tg := g.ToConstantDegree()
+
+	for _, limit := range []int{1, 2, 3, 8, 64, tg.G.V} {
+		solver := NewSolver(tg.G)
+		solver.BaseCaseLimit = limit
+		got := tg.MapDistances(solver.Run(tg.OriginalTo[0]))
+		for v := range want {
+			if got[v] != want[v] {
+				t.Fatalf("limit %d: dist[%d] = %v, want %v", limit, v, got[v], want[v])
+			}
+		}
+	}
+}
+
+func TestBaseCaseLimitValidated(t *testing.T) {
+	solver := NewSolver(graph.NewGraph(2))
+	solver.BaseCaseLimit = 0
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for BaseCaseLimit 0")
+		}
+	}()
+	solver.Run(0)
+}
+
+// TestBaseCaseDeterministicOrder gives many vertices the same distance and
+// shuffles both the adjacency lists and S between runs. Ties pop by vertex
+// id, so U must come back in the same order every time.
+func TestBaseCaseDeterministicOrder(t *testing.T) {
+	const leaves = 40
+	var want []int
+	for seed := int64(0); seed < 10; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		g := graph.NewGraph(2*leaves + 2)
+		for v := 2; v < leaves+2; v++ {
+			g.AddEdge(v%2, v, 1)
+			g.AddEdge(v, v+leaves, float64(v%3))
+		}
+		for u := range g.Adj {
+			rng.Shuffle(len(g.Adj[u]), func(i, j int) {
+				g.Adj[u][i], g.Adj[u][j] = g.Adj[u][j], g.Adj[u][i]
+			})
+		}
+		S := []int{0, 1}
+		rng.Shuffle(len(S), func(i, j int) { S[i], S[j] = S[j], S[i] })
+
+		solver := NewSolver(g)
+		solver.BaseCaseLimit = leaves
+		for i := range solver.Dist {
+			solver.Dist[i] = Infinity
+		}
+		solver.Dist[0], solver.Dist[1] = 0, 0
+
+		_, U := solver.BaseCase(Infinity, S)
+		if want == nil {
+			want = U
+			continue
+		}
+		if !slices.Equal(U, want) {
+			t.Fatalf("seed %d: U = %v, want %v", seed, U, want)
+		}
+	}
+}
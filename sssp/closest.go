@@ -0,0 +1,116 @@
+package sssp
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// VertexDist pairs a vertex with its distance.
+type VertexDist struct {
+	Vertex int
+	Dist   float64
+}
+
+// less orders by distance, then by vertex so ties are deterministic.
+func (a VertexDist) less(b VertexDist) bool {
+	if a.Dist != b.Dist {
+		return a.Dist < b.Dist
+	}
+	return a.Vertex < b.Vertex
+}
+
+// ClosestN returns the n reachable vertices of dist with the smallest
+// distance, in ascending order and ties broken by vertex. Vertices at
+// Infinity are skipped, so fewer than n may be returned. It keeps a bounded
+// heap of the best n seen, costing O(V log n) rather than a full sort.
+func ClosestN(dist []float64, n int) []VertexDist {
+	if n <= 0 {
+		return nil
+	}
+
+	h := make(farthestFirst, 0, min(n, len(dist)))
+	for v, d := range dist {
+		if isUnreachable(d) {
+			continue
+		}
+		c := VertexDist{Vertex: v, Dist: d}
+		if len(h) < n {
+			heap.Push(&h, c)
+		} else if c.less(h[0]) {
+			h[0] = c
+			heap.Fix(&h, 0)
+		}
+	}
+
+	res := []VertexDist(h)
+	sort.Slice(res, func(i, j int) bool { return res[i].less(res[j]) })
+	return res
+}
+
+// RunNearest solves from source only until the n nearest vertices of targets
+// are known, and returns them in ascending order with ties broken by vertex.
+// Fewer than n are returned if fewer targets are reachable. Vertices are
+// those of G, as for Run.
+//
+// BMSSP does not settle vertices in distance order, so the n-th target
+// settled need not be the n-th nearest. What it does guarantee is that when
+// BaseCase settles a vertex at distance d, every vertex nearer than d already
+// has its final distance, though it may not be settled yet. The run therefore
+// tracks c, the largest distance settled so far, and the n nearest targets
+// settled so far, and stops once all n of those lie strictly below c. Every
+// target below c is then final and any other is at least c away, so the
+// answer is the n nearest of the targets below c, found by one scan.
+//
+// After an early stop s.Dist is only valid below c; other vertices may hold
+// tentative distances.
+func (s *Solver) RunNearest(source int, targets map[int]bool, n int) []VertexDist {
+	if n <= 0 {
+		return nil
+	}
+
+	certified := 0.0
+	best := make(farthestFirst, 0, n)
+	s.onSettle = func(u int) bool {
+		d := s.Dist[u]
+		certified = max(certified, d)
+		if targets[u] {
+			c := VertexDist{Vertex: u, Dist: d}
+			if len(best) < n {
+				heap.Push(&best, c)
+			} else if c.less(best[0]) {
+				best[0] = c
+				heap.Fix(&best, 0)
+			}
+		}
+		return len(best) == n && best[0].Dist < certified
+	}
+	s.run(s.one(source), Infinity)
+	s.onSettle = nil
+
+	bound := Infinity
+	if s.stopped {
+		bound = certified
+	}
+	res := make([]VertexDist, 0, min(len(targets), s.G.V))
+	for v := range targets {
+		if d := s.Dist[v]; d < bound && !isUnreachable(d) {
+			res = append(res, VertexDist{Vertex: v, Dist: d})
+		}
+	}
+	sort.Slice(res, func(i, j int) bool { return res[i].less(res[j]) })
+	return res[:min(n, len(res))]
+}
+
+// farthestFirst is a max-heap of VertexDist: the root is the worst kept.
+type farthestFirst []VertexDist
+
+func (h farthestFirst) Len() int            { return len(h) }
+func (h farthestFirst) Less(i, j int) bool  { return h[j].less(h[i]) }
+func (h farthestFirst) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *farthestFirst) Push(x interface{}) { *h = append(*h, x.(VertexDist)) }
+func (h *farthestFirst) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
@@ -0,0 +1,107 @@
+package sssp
+
+import (
+	"math/rand"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/gen"
+)
+
+func TestClosestN(t *testing.T) {
+	dist := []float64{5, Infinity, 0, 3, 3, Infinity, 7, 1}
+
+	cases := []struct {
+		n    int
+		want []VertexDist
+	}{
+		{0, nil},
+		{1, []VertexDist{{2, 0}}},
+		{4, []VertexDist{{2, 0}, {7, 1}, {3, 3}, {4, 3}}},
+		{10, []VertexDist{{2, 0}, {7, 1}, {3, 3}, {4, 3}, {0, 5}, {6, 7}}},
+	}
+	for _, c := range cases {
+		if got := ClosestN(dist, c.n); !slices.Equal(got, c.want) {
+			t.Errorf("ClosestN(%d) = %v, want %v", c.n, got, c.want)
+		}
+	}
+}
+
+func TestClosestNMatchesSort(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	dist := make([]float64, 1000)
+	for i := range dist {
+		if rng.Intn(5) == 0 {
+			dist[i] = Infinity
+		} else {
+			dist[i] = float64(rng.Intn(200))
+		}
+	}
+
+	var all []VertexDist
+	for v, d := range dist {
+		if d < Infinity {
+			all = append(all, VertexDist{v, d})
+		}
+	}
+	sort.Slice(all, func(i, j int) bool { return all[i].less(all[j]) })
+
+	for _, n := range []int{1, 10, 100, len(all), len(dist)} {
+		want := all[:min(n, len(all))]
+		if got := ClosestN(dist, n); !slices.Equal(got, want) {
+			t.Errorf("n = %d: got %d items, differs from sorted prefix", n, len(got))
+		}
+	}
+}
+
+// TestRunNearestMatchesFullRun compares RunNearest with ClosestN over a full
+// run's distances to the targets, and checks that small n stop early.
+func TestRunNearestMatchesFullRun(t *testing.T) {
+	stoppedEarly := 0
+	for seed := int64(0); seed < 20; seed++ {
+		g := gen.Random(400, 1600, seed)
+		tg := g.ToConstantDegree()
+		rng := rand.New(rand.NewSource(seed))
+		source := tg.StartNode(rng.Intn(g.V))
+
+		targets := make(map[int]bool)
+		for len(targets) < 60 {
+			targets[rng.Intn(tg.G.V)] = true
+		}
+
+		solver := NewSolver(tg.G)
+		full := slices.Clone(solver.Run(source))
+		reachable := solver.settled
+		for v := range full {
+			if !targets[v] {
+				full[v] = Infinity
+			}
+		}
+
+		for _, n := range []int{0, 1, 5, 20, 60, 100} {
+			got := solver.RunNearest(source, targets, n)
+			if want := ClosestN(full, n); !slices.Equal(got, want) {
+				t.Fatalf("seed %d, n %d: got %v, want %v", seed, n, got, want)
+			}
+			if n == 1 && solver.settled < reachable {
+				stoppedEarly++
+			}
+		}
+	}
+	if stoppedEarly == 0 {
+		t.Error("RunNearest never stopped before settling every reachable vertex")
+	}
+
+	// A later full run must not see the early stop
+	g := gen.Random(400, 1600, 1)
+	solver := NewSolver(g)
+	solver.RunNearest(0, map[int]bool{0: true}, 1)
+	got := solver.Run(0)
+	want := Dijkstra(g, 0)
+	for v := range want {
+		if got[v] != want[v] {
+			t.Fatalf("after RunNearest: dist[%d] = %v, want %v", v, got[v], want[v])
+		}
+	}
+}
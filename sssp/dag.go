@@ -0,0 +1,66 @@
+package sssp
+
+import (
+	"errors"
+
+	"github.com/phr3nzy/duan-sssp/graph"
+)
+
+// ErrNotDAG is returned by DAGShortestPaths when the graph has a cycle.
+var ErrNotDAG = errors.New("sssp: graph is not acyclic")
+
+// DAGShortestPaths computes distances from source in O(V+E) by relaxing
+// edges in topological order. It returns ErrNotDAG if g has a cycle,
+// including a self-loop. Unreachable vertices are left at Infinity.
+func DAGShortestPaths(g *graph.Graph, source int) ([]float64, error) {
+	order, ok := topoOrder(g)
+	if !ok {
+		return nil, ErrNotDAG
+	}
+
+	dist := make([]float64, g.V)
+	for i := range dist {
+		dist[i] = Infinity
+	}
+	dist[source] = 0
+
+	for _, u := range order {
+		if isUnreachable(dist[u]) {
+			continue
+		}
+		for _, e := range g.Adj[u] {
+			if d := dist[u] + e.Weight; d < dist[e.To] {
+				dist[e.To] = d
+			}
+		}
+	}
+	return dist, nil
+}
+
+// topoOrder returns g's vertices in topological order using Kahn's
+// algorithm, or false if g has a cycle.
+func topoOrder(g *graph.Graph) ([]int, bool) {
+	inDegree := make([]int, g.V)
+	for u := range g.Adj {
+		for _, e := range g.Adj[u] {
+			inDegree[e.To]++
+		}
+	}
+
+	order := make([]int, 0, g.V)
+	for u, d := range inDegree {
+		if d == 0 {
+			order = append(order, u)
+		}
+	}
+	// order doubles as the queue: entries before i have been expanded
+	for i := 0; i < len(order); i++ {
+		for _, e := range g.Adj[order[i]] {
+			inDegree[e.To]--
+			if inDegree[e.To] == 0 {
+				order = append(order, e.To)
+			}
+		}
+	}
+	return order, len(order) == g.V
+}
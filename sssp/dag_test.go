@@ -0,0 +1,56 @@
+package sssp
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/graph"
+)
+
+// randomDAG only adds edges from lower to higher vertex ids.
+func randomDAG(n, m int, seed int64) *graph.Graph {
+	rng := rand.New(rand.NewSource(seed))
+	g := graph.NewGraph(n)
+	for i := 0; i < m; i++ {
+		u, v := rng.Intn(n), rng.Intn(n)
+		if u == v {
+			continue
+		}
+		g.AddEdge(min(u, v), max(u, v), rng.Float64()*100)
+	}
+	return g
+}
+
+func TestDAGShortestPathsMatchesDijkstra(t *testing.T) {
+	for seed := int64(0); seed < 20; seed++ {
+		g := randomDAG(300, 1200, seed)
+		source := int(seed) * 7
+		got, err := DAGShortestPaths(g, source)
+		if err != nil {
+			t.Fatalf("seed %d: %v", seed, err)
+		}
+		want := Dijkstra(g, source)
+		for v := range want {
+			if got[v] != want[v] {
+				t.Fatalf("seed %d: dist[%d] = %v, want %v", seed, v, got[v], want[v])
+			}
+		}
+	}
+}
+
+func TestDAGShortestPathsRejectsCycles(t *testing.T) {
+	cycle := randomDAG(50, 200, 1)
+	cycle.AddEdge(40, 10, 1)
+	cycle.AddEdge(10, 40, 1)
+
+	selfLoop := graph.NewGraph(3)
+	selfLoop.AddEdge(0, 1, 1)
+	selfLoop.AddEdge(1, 1, 0)
+
+	for name, g := range map[string]*graph.Graph{"cycle": cycle, "self-loop": selfLoop} {
+		if _, err := DAGShortestPaths(g, 0); !errors.Is(err, ErrNotDAG) {
+			t.Errorf("%s: err = %v, want ErrNotDAG", name, err)
+		}
+	}
+}
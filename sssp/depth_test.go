@@ -0,0 +1,26 @@
+package sssp
+
+import (
+	"math"
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/gen"
+)
+
+func TestMaxDepthWithinBound(t *testing.T) {
+	for _, n := range []int{10, 100, 1000, 5000} {
+		g := gen.Random(n, 3*n, 1)
+		tg := g.ToConstantDegree()
+		solver := NewSolver(tg.G)
+		solver.Run(tg.OriginalTo[0])
+
+		l := int(math.Ceil(math.Log2(float64(tg.G.V)) / float64(solver.T)))
+		if solver.MaxDepth() > l {
+			t.Errorf("n=%d: max depth %d exceeds l=%d", n, solver.MaxDepth(), l)
+		}
+		if solver.MaxDepth() == 0 && l > 0 {
+			t.Errorf("n=%d: recursion never descended below the top level", n)
+		}
+		t.Logf("n=%d (transformed %d): max depth %d, l=%d", n, tg.G.V, solver.MaxDepth(), l)
+	}
+}
@@ -0,0 +1,54 @@
+package sssp
+
+import (
+	"container/heap"
+
+	"github.com/phr3nzy/duan-sssp/graph"
+)
+
+// Dijkstra computes single-source shortest paths with a binary heap and lazy
+// deletion. It is the reference the other algorithms are checked against.
+// Unreachable vertices are left at Infinity.
+func Dijkstra(g *graph.Graph, source int) []float64 {
+	dist, _ := dijkstra(g, source, nil)
+	return dist
+}
+
+// dijkstra is Dijkstra with predecessors. Edges for which skip returns true
+// are ignored; skip may be nil.
+func dijkstra(g *graph.Graph, source int, skip func(u int, e graph.Edge) bool) ([]float64, []int) {
+	dist := make([]float64, g.V)
+	pred := make([]int, g.V)
+	for i := range dist {
+		dist[i] = Infinity
+		pred[i] = -1
+	}
+	dist[source] = 0
+
+	pq := &PriorityQueue{}
+	heap.Push(pq, &PQItem{u: source, priority: 0})
+
+	for pq.Len() > 0 {
+		item := heap.Pop(pq).(*PQItem)
+		u := item.u
+
+		// Skip stale entries
+		if item.priority > dist[u] {
+			continue
+		}
+
+		for _, edge := range g.Adj[u] {
+			if skip != nil && skip(u, edge) {
+				continue
+			}
+			newDist := dist[u] + edge.Weight
+			if newDist < dist[edge.To] {
+				dist[edge.To] = newDist
+				pred[edge.To] = u
+				heap.Push(pq, &PQItem{u: edge.To, priority: newDist})
+			}
+		}
+	}
+
+	return dist, pred
+}
@@ -0,0 +1,59 @@
+package sssp
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/graph"
+)
+
+func TestDijkstra(t *testing.T) {
+	g := graph.NewGraph(5)
+	g.AddEdge(0, 1, 4)
+	g.AddEdge(0, 2, 1)
+	g.AddEdge(2, 1, 2)
+	g.AddEdge(1, 3, 1)
+	g.AddEdge(3, 1, 0)
+
+	dist := Dijkstra(g, 0)
+	want := []float64{0, 3, 1, 4, Infinity}
+	for v := range want {
+		if dist[v] != want[v] {
+			t.Errorf("dist[%d] = %v, want %v", v, dist[v], want[v])
+		}
+	}
+}
+
+// TestDuanMatchesDijkstra checks Run+MapDistances against Dijkstra on random
+// graphs of varying size and density. Even seeds use small integer weights,
+// including zero, to exercise ties.
+func TestDuanMatchesDijkstra(t *testing.T) {
+	for seed := int64(0); seed < 200; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		vertices := 2 + rng.Intn(400)
+		edges := vertices * (1 + rng.Intn(8))
+
+		g := graph.NewGraph(vertices)
+		for i := 0; i < edges; i++ {
+			w := rng.Float64() * 100.0
+			if seed%2 == 0 {
+				w = float64(rng.Intn(10))
+			}
+			g.AddEdge(rng.Intn(vertices), rng.Intn(vertices), w)
+		}
+
+		source := rng.Intn(vertices)
+		tg := g.ToConstantDegree()
+		solver := NewSolver(tg.G)
+		got := tg.MapDistances(solver.Run(tg.OriginalTo[source]))
+		want := Dijkstra(g, source)
+
+		for v := range want {
+			if math.Abs(got[v]-want[v]) > 1e-9 {
+				t.Fatalf("seed %d (V=%d, E=%d, source=%d): dist[%d] = %v, want %v",
+					seed, vertices, edges, source, v, got[v], want[v])
+			}
+		}
+	}
+}
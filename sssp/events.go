@@ -0,0 +1,39 @@
+package sssp
+
+// EventListener receives callbacks as the solver makes progress.
+// Discover and relax events fire only when a distance strictly improves,
+// so every value a vertex's distance takes is reported exactly once.
+//
+// All callbacks are made from the goroutine that called Run, including
+// during parallel relaxation, so listeners need no locking of their own.
+type EventListener interface {
+	// OnNodeDiscovered fires when a vertex first gets a finite distance.
+	// from is the tail of the relaxed edge, or -1 for the source itself.
+	OnNodeDiscovered(from, to int, dist float64)
+	// OnNodeRelaxed fires when an already-discovered vertex improves.
+	OnNodeRelaxed(from, to int, oldDist, newDist float64)
+	// OnNodeSettled fires when the base case settles a vertex.
+	OnNodeSettled(node int, dist float64)
+	OnPhaseChange(phase string, level int)
+	OnIterationComplete(settled int)
+	// OnBoundUpdate fires after each main-loop iteration of a BMSSP call at
+	// level, with the bound Bi pulled from its data structure and the bound
+	// BiPrime <= Bi the recursive call returned.
+	OnBoundUpdate(level int, Bi, BiPrime float64)
+	// OnTreeEdge fires once per reachable vertex other than a source, when
+	// it is settled and its predecessor can no longer change. weight is the
+	// edge parent->child the shortest path uses. With zero-weight edges a
+	// child may be reported before its parent.
+	OnTreeEdge(parent, child int, weight float64)
+}
+
+// NoOpListener ignores every event.
+type NoOpListener struct{}
+
+func (*NoOpListener) OnNodeDiscovered(from, to int, dist float64)          {}
+func (*NoOpListener) OnNodeRelaxed(from, to int, oldDist, newDist float64) {}
+func (*NoOpListener) OnNodeSettled(node int, dist float64)                 {}
+func (*NoOpListener) OnPhaseChange(phase string, level int)                {}
+func (*NoOpListener) OnIterationComplete(settled int)                      {}
+func (*NoOpListener) OnBoundUpdate(level int, Bi, BiPrime float64)         {}
+func (*NoOpListener) OnTreeEdge(parent, child int, weight float64)         {}
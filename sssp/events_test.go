@@ -0,0 +1,196 @@
+package sssp
+
+import (
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/gen"
+)
+
+type recordedEdge struct {
+	from, to int
+	dist     float64
+}
+
+// recordingListener keeps every discover/relax event in order.
+type recordingListener struct {
+	NoOpListener
+	edges []recordedEdge
+}
+
+func (r *recordingListener) OnNodeDiscovered(from, to int, dist float64) {
+	r.edges = append(r.edges, recordedEdge{from, to, dist})
+}
+
+func (r *recordingListener) OnNodeRelaxed(from, to int, oldDist, newDist float64) {
+	r.edges = append(r.edges, recordedEdge{from, to, newDist})
+}
+
+// TestListenerReportsTreeEdges checks that the edge which gave each vertex its
+// final distance is reported exactly once, including for first discoveries.
+func TestListenerReportsTreeEdges(t *testing.T) {
+	g := gen.Random(200, 600, 1)
+	tg := g.ToConstantDegree()
+	solver := NewSolver(tg.G)
+	rec := &recordingListener{}
+	solver.SetEventListener(rec)
+
+	source := tg.OriginalTo[0]
+	dist := solver.Run(source)
+
+	treeEdges := make(map[int]int)
+	for _, e := range rec.edges {
+		if e.dist != dist[e.to] {
+			continue
+		}
+		treeEdges[e.to]++
+
+		if e.to == source {
+			if e.from != -1 {
+				t.Errorf("source reported with parent %d, want -1", e.from)
+			}
+			continue
+		}
+
+		found := false
+		for _, edge := range tg.G.Adj[e.from] {
+			if edge.To == e.to {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("reported tree edge %d->%d does not exist", e.from, e.to)
+		}
+	}
+
+	for v, d := range dist {
+		if d == Infinity {
+			continue
+		}
+		if treeEdges[v] != 1 {
+			t.Errorf("vertex %d: tree edge reported %d times, want 1", v, treeEdges[v])
+		}
+	}
+}
+
+// TestListenerParallelRelaxation drives the parallel relaxation path with a
+// non-synchronized listener; run with -race to catch concurrent callbacks.
+func TestListenerParallelRelaxation(t *testing.T) {
+	g := gen.Random(2000, 8000, 1)
+	tg := g.ToConstantDegree()
+	solver := NewSolver(tg.G)
+	solver.SetWorkers(4) // force the parallel path even on one CPU
+	rec := &recordingListener{}
+	solver.SetEventListener(rec)
+
+	dist := solver.Run(tg.OriginalTo[0])
+
+	reached := 0
+	for _, d := range dist {
+		if d < Infinity {
+			reached++
+		}
+	}
+	if len(rec.edges) < reached {
+		t.Errorf("recorded %d events for %d reached vertices", len(rec.edges), reached)
+	}
+}
+
+type boundEvent struct {
+	level       int
+	bi, biPrime float64
+}
+
+type boundListener struct {
+	NoOpListener
+	bounds []boundEvent
+}
+
+func (b *boundListener) OnBoundUpdate(level int, Bi, BiPrime float64) {
+	b.bounds = append(b.bounds, boundEvent{level, Bi, BiPrime})
+}
+
+func TestBoundUpdates(t *testing.T) {
+	tg := gen.Random(300, 1200, 5).ToConstantDegree()
+	solver := NewSolver(tg.G)
+	rec := &boundListener{}
+	solver.SetEventListener(rec)
+	solver.Run(tg.OriginalTo[0])
+
+	if len(rec.bounds) == 0 {
+		t.Fatal("no bound updates recorded")
+	}
+	for i, e := range rec.bounds {
+		if e.level < 1 {
+			t.Errorf("event %d: level %d, main loop only runs above the base case", i, e.level)
+		}
+		if e.biPrime < 0 || e.biPrime > e.bi || e.bi > Infinity {
+			t.Errorf("event %d: bounds out of order: 0 <= %v <= %v <= Infinity", i, e.biPrime, e.bi)
+		}
+	}
+}
+
+type treeEdge struct {
+	parent, child int
+	weight        float64
+}
+
+type treeListener struct {
+	NoOpListener
+	edges []treeEdge
+}
+
+func (tl *treeListener) OnTreeEdge(parent, child int, weight float64) {
+	tl.edges = append(tl.edges, treeEdge{parent, child, weight})
+}
+
+// TestTreeEdges checks that OnTreeEdge reports a shortest-path tree: one edge
+// per reachable non-source vertex, each an existing edge on a shortest path,
+// and every vertex leads back to the source through its parents.
+func TestTreeEdges(t *testing.T) {
+	for _, workers := range []int{1, 4} {
+		tg := gen.Random(500, 2000, 3).ToConstantDegree()
+		solver := NewSolver(tg.G)
+		solver.SetWorkers(workers)
+		rec := &treeListener{}
+		solver.SetEventListener(rec)
+
+		source := tg.OriginalTo[0]
+		dist := solver.Run(source)
+
+		reachable := solver.ReachableCount(dist)
+		if len(rec.edges) != reachable-1 {
+			t.Fatalf("workers=%d: %d tree edges for %d reachable vertices", workers, len(rec.edges), reachable)
+		}
+
+		parent := make(map[int]int)
+		for _, e := range rec.edges {
+			if _, dup := parent[e.child]; dup || e.child == source {
+				t.Fatalf("workers=%d: vertex %d attached twice", workers, e.child)
+			}
+			found := false
+			for _, edge := range tg.G.Adj[e.parent] {
+				if edge.To == e.child && edge.Weight == e.weight {
+					found = true
+				}
+			}
+			if !found {
+				t.Fatalf("workers=%d: tree edge %d->%d (w=%v) does not exist", workers, e.parent, e.child, e.weight)
+			}
+			if dist[e.parent]+e.weight != dist[e.child] {
+				t.Fatalf("workers=%d: edge %d->%d not tight: %v + %v != %v", workers, e.parent, e.child, dist[e.parent], e.weight, dist[e.child])
+			}
+			parent[e.child] = e.parent
+		}
+
+		for v := range parent {
+			steps := 0
+			for u := v; u != source; u = parent[u] {
+				if _, ok := parent[u]; !ok || steps > len(parent) {
+					t.Fatalf("workers=%d: vertex %d does not lead back to the source", workers, v)
+				}
+				steps++
+			}
+		}
+	}
+}
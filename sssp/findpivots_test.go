@@ -0,0 +1,108 @@
+package sssp
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/graph"
+)
+
+// TestFindPivotsWideFrontier uses a star whose first layer alone exceeds
+// K*|S|: every source must become a pivot, W must stop growing just past
+// the limit, and every leaf must still be relaxed.
+func TestFindPivotsWideFrontier(t *testing.T) {
+	const leaves = 500
+	g := graph.NewGraph(leaves + 1)
+	for v := 1; v <= leaves; v++ {
+		g.AddEdge(0, v, float64(v))
+	}
+
+	solver := NewSolver(g)
+	for i := range solver.Dist {
+		solver.Dist[i] = Infinity
+	}
+	solver.Dist[0] = 0
+
+	S := []int{0}
+	P, W := solver.FindPivots(Infinity, S)
+
+	if len(P) != 1 || P[0] != 0 {
+		t.Errorf("P = %v, want S = %v", P, S)
+	}
+	if limit := solver.K * len(S); len(W) > limit+1 {
+		t.Errorf("len(W) = %d, want at most %d", len(W), limit+1)
+	}
+	for v := 1; v <= leaves; v++ {
+		if solver.Dist[v] != float64(v) {
+			t.Fatalf("dist[%d] = %v, want %v", v, solver.Dist[v], float64(v))
+		}
+	}
+	for v := range solver.inW {
+		if solver.inW[v] || solver.treeSize[v] != 0 {
+			t.Fatalf("scratch state for %d not cleared", v)
+		}
+	}
+}
+
+// TestWideFrontierDistances runs the whole pipeline on a star feeding into a
+// chain, where FindPivots cuts W short at every level.
+func TestWideFrontierDistances(t *testing.T) {
+	const leaves = 2000
+	g := graph.NewGraph(2*leaves + 1)
+	for v := 1; v <= leaves; v++ {
+		g.AddEdge(0, v, float64(v%7))
+		g.AddEdge(v, leaves+v, 1)
+		if v > 1 {
+			g.AddEdge(leaves+v, leaves+v-1, 0.5)
+		}
+	}
+
+	got := ShortestPaths(g, 0)
+	want := Dijkstra(g, 0)
+	for v := range want {
+		if got[v] != want[v] {
+			t.Fatalf("dist[%d] = %v, want %v", v, got[v], want[v])
+		}
+	}
+}
+
+// TestFindPivotsLargeWeights gives source a an edge to v that is tight up to
+// one ulp at 1e15, as if v's distance had been rounded along another path,
+// while source b reaches v exactly. v heads a chain of three, so a's tree has
+// K+1 vertices only if the a->v edge counts as tight.
+func TestFindPivotsLargeWeights(t *testing.T) {
+	const a, b, v, y, z = 0, 1, 2, 3, 4
+	g := graph.NewGraph(5)
+	g.AddEdge(a, v, 0.3)
+	g.AddEdge(b, v, 1)
+	g.AddEdge(v, y, 0)
+	g.AddEdge(y, z, 0)
+
+	for _, tc := range []struct {
+		epsilon float64
+		wantA   bool
+	}{
+		{0, false},     // exact comparison misses the rounded edge
+		{1e-18, false}, // 1e-18 * 1e15 is still below the 0.125 ulp
+		{1e-9, true},   // the default; an absolute 1e-9 would have missed it
+	} {
+		solver := NewSolver(g)
+		solver.K = 3
+		solver.Epsilon = tc.epsilon
+		for i := range solver.Dist {
+			solver.Dist[i] = Infinity
+		}
+		solver.Dist[a] = 1e15
+		solver.Dist[v] = math.Nextafter(solver.Dist[a]+0.3, 0)
+		solver.Dist[b] = solver.Dist[v] - 1
+
+		P, _ := solver.FindPivots(Infinity, []int{a, b})
+		if !slices.Contains(P, b) {
+			t.Errorf("Epsilon %g: P = %v, missing b", tc.epsilon, P)
+		}
+		if got := slices.Contains(P, a); got != tc.wantA {
+			t.Errorf("Epsilon %g: a in P = %v, want %v", tc.epsilon, got, tc.wantA)
+		}
+	}
+}
@@ -0,0 +1,19 @@
+package sssp
+
+import "github.com/phr3nzy/duan-sssp/ds"
+
+// Frontier is the partial-sorting structure of Lemma 3.3 as BMSSP uses it.
+// *ds.DataStructure implements it; see Solver.NewFrontier.
+type Frontier interface {
+	// Insert adds key with value val. A key may be stored more than once.
+	Insert(key int, val float64)
+	// BatchPrepend adds items whose values are all below every stored value.
+	BatchPrepend(items []ds.Item)
+	// Pull removes and returns up to M smallest items, extended by ties, and
+	// the bound separating them from the rest, or B when none remain.
+	Pull() ([]ds.Item, float64)
+	// Len returns the number of stored items.
+	Len() int
+}
+
+var _ Frontier = (*ds.DataStructure)(nil)
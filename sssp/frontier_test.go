@@ -0,0 +1,64 @@
+package sssp
+
+import (
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/ds"
+	"github.com/phr3nzy/duan-sssp/gen"
+)
+
+// sliceFrontier keeps every item in one slice sorted by value.
+type sliceFrontier struct {
+	m     int
+	b     float64
+	items []ds.Item
+}
+
+func (f *sliceFrontier) Insert(key int, val float64) {
+	i := sort.Search(len(f.items), func(i int) bool { return f.items[i].Value > val })
+	f.items = slices.Insert(f.items, i, ds.Item{Key: key, Value: val})
+}
+
+func (f *sliceFrontier) BatchPrepend(items []ds.Item) {
+	for _, it := range items {
+		f.Insert(it.Key, it.Value)
+	}
+}
+
+func (f *sliceFrontier) Pull() ([]ds.Item, float64) {
+	n := min(f.m, len(f.items))
+	for n > 0 && n < len(f.items) && f.items[n].Value == f.items[n-1].Value {
+		n++
+	}
+	pulled := slices.Clone(f.items[:n])
+	f.items = f.items[n:]
+	if len(f.items) == 0 {
+		return pulled, f.b
+	}
+	return pulled, f.items[0].Value
+}
+
+func (f *sliceFrontier) Len() int { return len(f.items) }
+
+func TestNewFrontierSliceMatches(t *testing.T) {
+	for seed := int64(0); seed < 5; seed++ {
+		tg := gen.Random(1500, 6000, seed).ToConstantDegree()
+		source := tg.OriginalTo[int(seed)]
+		want := slices.Clone(NewSolver(tg.G).Run(source))
+
+		solver := NewSolver(tg.G)
+		calls := 0
+		solver.NewFrontier = func(m int, B float64) Frontier {
+			calls++
+			return &sliceFrontier{m: m, b: B}
+		}
+		if got := solver.Run(source); !slices.Equal(got, want) {
+			t.Fatalf("seed %d: distances with sliceFrontier differ", seed)
+		}
+		if calls == 0 {
+			t.Fatalf("seed %d: NewFrontier never called", seed)
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package sssp
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/phr3nzy/duan-sssp/graph"
+)
+
+// Path is a vertex sequence and its total cost.
+type Path struct {
+	Vertices []int
+	Cost     float64
+}
+
+// KShortest returns up to k loopless paths from source to target in
+// non-decreasing cost order, using Yen's algorithm. Parallel edges count as
+// one path through their cheapest edge. The result is empty when target is
+// unreachable.
+func KShortest(g *graph.Graph, source, target, k int) ([]Path, error) {
+	if source < 0 || source >= g.V || target < 0 || target >= g.V {
+		return nil, fmt.Errorf("sssp: source %d or target %d out of range [0,%d)", source, target, g.V)
+	}
+	if k < 1 {
+		return nil, fmt.Errorf("sssp: k must be positive, got %d", k)
+	}
+
+	first, ok := shortestPath(g, source, target, nil)
+	if !ok {
+		return nil, nil
+	}
+	paths := []Path{first}
+	var candidates []Path
+
+	blockedV := make([]bool, g.V)
+	blockedE := make(map[[2]int]bool)
+	skip := func(u int, e graph.Edge) bool {
+		return blockedV[e.To] || blockedE[[2]int{u, e.To}]
+	}
+
+	for len(paths) < k {
+		prev := paths[len(paths)-1].Vertices
+
+		for i := 0; i < len(prev)-1; i++ {
+			spur := prev[i]
+			root := prev[:i+1]
+
+			// Block the next edge of every accepted path sharing this root,
+			// and the root itself so the spur path stays loopless.
+			clear(blockedE)
+			for _, p := range paths {
+				if len(p.Vertices) > i+1 && slices.Equal(p.Vertices[:i+1], root) {
+					blockedE[[2]int{p.Vertices[i], p.Vertices[i+1]}] = true
+				}
+			}
+			for _, v := range root[:i] {
+				blockedV[v] = true
+			}
+
+			spurPath, ok := shortestPath(g, spur, target, skip)
+
+			for _, v := range root[:i] {
+				blockedV[v] = false
+			}
+			if !ok {
+				continue
+			}
+
+			vertices := append(slices.Clone(root[:i]), spurPath.Vertices...)
+			cand := Path{Vertices: vertices, Cost: pathCost(g, root) + spurPath.Cost}
+			if !containsPath(candidates, cand) && !containsPath(paths, cand) {
+				candidates = append(candidates, cand)
+			}
+		}
+
+		if len(candidates) == 0 {
+			break
+		}
+		best := 0
+		for i, c := range candidates {
+			if c.Cost < candidates[best].Cost {
+				best = i
+			}
+		}
+		paths = append(paths, candidates[best])
+		candidates = slices.Delete(candidates, best, best+1)
+	}
+
+	return paths, nil
+}
+
+// shortestPath runs dijkstra from source and extracts the path to target.
+func shortestPath(g *graph.Graph, source, target int, skip func(u int, e graph.Edge) bool) (Path, bool) {
+	dist, pred := dijkstra(g, source, skip)
+	if isUnreachable(dist[target]) {
+		return Path{}, false
+	}
+
+	var vertices []int
+	for v := target; v != -1; v = pred[v] {
+		vertices = append(vertices, v)
+	}
+	slices.Reverse(vertices)
+	return Path{Vertices: vertices, Cost: dist[target]}, true
+}
+
+// pathCost sums the cheapest edge between each consecutive pair of vertices.
+func pathCost(g *graph.Graph, vertices []int) float64 {
+	cost := 0.0
+	for i := 0; i+1 < len(vertices); i++ {
+		w := Infinity
+		for _, e := range g.Adj[vertices[i]] {
+			if e.To == vertices[i+1] && e.Weight < w {
+				w = e.Weight
+			}
+		}
+		cost += w
+	}
+	return cost
+}
+
+func containsPath(paths []Path, p Path) bool {
+	for _, q := range paths {
+		if slices.Equal(q.Vertices, p.Vertices) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,113 @@
+package sssp
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/graph"
+)
+
+// yenExample is the standard Yen's algorithm example with vertices C..H as 0..5.
+func yenExample() *graph.Graph {
+	g := graph.NewGraph(6)
+	g.AddEdge(0, 1, 3) // C->D
+	g.AddEdge(0, 2, 2) // C->E
+	g.AddEdge(1, 3, 4) // D->F
+	g.AddEdge(2, 1, 1) // E->D
+	g.AddEdge(2, 3, 2) // E->F
+	g.AddEdge(2, 4, 3) // E->G
+	g.AddEdge(3, 4, 2) // F->G
+	g.AddEdge(3, 5, 1) // F->H
+	g.AddEdge(4, 5, 2) // G->H
+	return g
+}
+
+func TestKShortest(t *testing.T) {
+	paths, err := KShortest(yenExample(), 0, 5, 4)
+	if err != nil {
+		t.Fatalf("KShortest: %v", err)
+	}
+	if len(paths) != 4 {
+		t.Fatalf("got %d paths, want 4", len(paths))
+	}
+
+	want := []Path{
+		{Vertices: []int{0, 2, 3, 5}, Cost: 5},
+		{Vertices: []int{0, 2, 4, 5}, Cost: 7},
+	}
+	for i, w := range want {
+		if !slices.Equal(paths[i].Vertices, w.Vertices) || paths[i].Cost != w.Cost {
+			t.Errorf("path %d = %+v, want %+v", i, paths[i], w)
+		}
+	}
+
+	// Three paths tie at cost 8; any two of them may come next
+	tied := [][]int{{0, 1, 3, 5}, {0, 2, 1, 3, 5}, {0, 2, 3, 4, 5}}
+	for _, p := range paths[2:] {
+		if p.Cost != 8 || !slices.ContainsFunc(tied, func(v []int) bool { return slices.Equal(v, p.Vertices) }) {
+			t.Errorf("unexpected path %+v", p)
+		}
+	}
+}
+
+// TestKShortestAll asks for more paths than exist and checks that every
+// simple path is returned once, loopless and in cost order.
+func TestKShortestAll(t *testing.T) {
+	g := yenExample()
+	paths, err := KShortest(g, 0, 5, 100)
+	if err != nil {
+		t.Fatalf("KShortest: %v", err)
+	}
+
+	// Enumerate simple paths by DFS
+	count := 0
+	onPath := make([]bool, g.V)
+	var dfs func(u int)
+	dfs = func(u int) {
+		if u == 5 {
+			count++
+			return
+		}
+		onPath[u] = true
+		for _, e := range g.Adj[u] {
+			if !onPath[e.To] {
+				dfs(e.To)
+			}
+		}
+		onPath[u] = false
+	}
+	dfs(0)
+
+	if len(paths) != count {
+		t.Fatalf("got %d paths, graph has %d simple paths", len(paths), count)
+	}
+	for i, p := range paths {
+		if i > 0 && p.Cost < paths[i-1].Cost {
+			t.Errorf("path %d cost %v below previous %v", i, p.Cost, paths[i-1].Cost)
+		}
+		seen := make(map[int]bool)
+		for _, v := range p.Vertices {
+			if seen[v] {
+				t.Errorf("path %v repeats vertex %d", p.Vertices, v)
+			}
+			seen[v] = true
+		}
+		if p.Cost != pathCost(g, p.Vertices) {
+			t.Errorf("path %v cost %v, edges sum to %v", p.Vertices, p.Cost, pathCost(g, p.Vertices))
+		}
+	}
+}
+
+func TestKShortestErrors(t *testing.T) {
+	g := yenExample()
+	if _, err := KShortest(g, 0, 6, 1); err == nil {
+		t.Error("expected error for out-of-range target")
+	}
+	if _, err := KShortest(g, 0, 5, 0); err == nil {
+		t.Error("expected error for k = 0")
+	}
+	paths, err := KShortest(g, 5, 0, 3)
+	if err != nil || len(paths) != 0 {
+		t.Errorf("unreachable target: got %v, %v", paths, err)
+	}
+}
@@ -0,0 +1,25 @@
+package sssp
+
+import (
+	"fmt"
+
+	"github.com/phr3nzy/duan-sssp/graph"
+)
+
+// LabeledDistances transforms lg's graph, runs the solver from the vertex
+// named source and returns the distance to every label. Unreachable labels
+// map to Infinity.
+func LabeledDistances(lg *graph.LabeledGraph, source string) (map[string]float64, error) {
+	src, ok := lg.Index(source)
+	if !ok {
+		return nil, fmt.Errorf("sssp: unknown source label %q", source)
+	}
+
+	dist := ShortestPaths(lg.G, src)
+
+	res := make(map[string]float64, len(dist))
+	for i, d := range dist {
+		res[lg.Label(i)] = d
+	}
+	return res, nil
+}
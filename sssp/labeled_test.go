@@ -0,0 +1,33 @@
+package sssp
+
+import (
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/graph"
+)
+
+func TestLabeledDistances(t *testing.T) {
+	lg := graph.NewLabeledGraph()
+	lg.AddEdge("nodeA", "junction_42", 2)
+	lg.AddEdge("junction_42", "nodeB", 3)
+	lg.AddEdge("nodeA", "nodeB", 10)
+	lg.AddEdge("nodeC", "nodeA", 1)
+
+	dist, err := LabeledDistances(lg, "nodeA")
+	if err != nil {
+		t.Fatalf("LabeledDistances: %v", err)
+	}
+	want := map[string]float64{"nodeA": 0, "junction_42": 2, "nodeB": 5, "nodeC": Infinity}
+	if len(dist) != len(want) {
+		t.Fatalf("got %d labels, want %d", len(dist), len(want))
+	}
+	for label, d := range want {
+		if dist[label] != d {
+			t.Errorf("%s: %v, want %v", label, dist[label], d)
+		}
+	}
+
+	if _, err := LabeledDistances(lg, "missing"); err == nil {
+		t.Error("expected error for unknown source")
+	}
+}
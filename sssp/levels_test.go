@@ -0,0 +1,72 @@
+package sssp
+
+import (
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/gen"
+)
+
+func TestLevelCountsSumToReachable(t *testing.T) {
+	tg := gen.Random(5000, 15000, 6).ToConstantDegree()
+	solver := NewSolver(tg.G)
+
+	for _, src := range []int{0, 42, 4999} {
+		dist := solver.Run(tg.OriginalTo[src])
+		reachable := 0
+		for _, d := range dist {
+			if d < Infinity {
+				reachable++
+			}
+		}
+
+		counts := solver.LevelCounts()
+		l := solver.MaxDepth()
+		if len(counts) <= l {
+			t.Fatalf("source %d: %d levels counted, recursion reached depth %d", src, len(counts), l)
+		}
+		sum := 0
+		for _, c := range counts {
+			if c < 0 {
+				t.Fatalf("source %d: negative count in %v", src, counts)
+			}
+			sum += c
+		}
+		if sum != reachable {
+			t.Errorf("source %d: level counts %v sum to %d, want %d reachable", src, counts, sum, reachable)
+		}
+	}
+}
+
+func TestBlockSizes(t *testing.T) {
+	tg := gen.Random(5000, 15000, 6).ToConstantDegree()
+	solver := NewSolver(tg.G)
+	solver.Run(tg.OriginalTo[0])
+
+	sizes := solver.BlockSizes()
+	if len(sizes) != solver.topLevel()+1 {
+		t.Fatalf("%d levels recorded, want %d", len(sizes), solver.topLevel()+1)
+	}
+	if sizes[0] != 0 {
+		t.Errorf("level 0 block size %d, want 0 (base case)", sizes[0])
+	}
+	built := 0
+	for l := 1; l < len(sizes); l++ {
+		if sizes[l] == 0 {
+			continue
+		}
+		built++
+		if want := 1 << ((l - 1) * solver.T); sizes[l] != want {
+			t.Errorf("level %d: M = %d, want 2^((%d-1)*%d) = %d", l, sizes[l], l, solver.T, want)
+		}
+	}
+	if sizes[len(sizes)-1] == 0 || built == 0 {
+		t.Errorf("top level built no data structure: %v", sizes)
+	}
+
+	solver.Warmup(tg.OriginalTo[0])
+	for l, m := range solver.BlockSizes() {
+		if m != 0 {
+			t.Errorf("after Warmup: level %d still reports %d", l, m)
+		}
+	}
+}
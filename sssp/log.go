@@ -0,0 +1,65 @@
+package sssp
+
+import (
+	"context"
+	"log/slog"
+)
+
+// SetLogger makes subsequent runs log to l at debug level: when a run
+// starts, the transformation's size if SetTransform was called, every BMSSP
+// phase change, and the run's statistics when it finishes. nil, the default,
+// disables logging. Whether l is enabled for debug is checked once per run,
+// so a disabled logger costs a branch per phase change.
+func (s *Solver) SetLogger(l *slog.Logger) {
+	s.logger = l
+}
+
+// startLog decides whether this run logs and, if so, logs its start.
+func (s *Solver) startLog(sources []int, B float64) {
+	s.logDebug = s.logger != nil && s.logger.Enabled(context.Background(), slog.LevelDebug)
+	if !s.logDebug {
+		return
+	}
+	s.logger.LogAttrs(context.Background(), slog.LevelDebug, "sssp: run started",
+		slog.Any("sources", sources),
+		slog.Float64("bound", B),
+		slog.Int("vertices", s.G.V),
+		slog.Int("k", s.K),
+		slog.Int("t", s.T),
+		slog.Int("levels", s.topLevel()))
+	if s.tg != nil {
+		s.logger.LogAttrs(context.Background(), slog.LevelDebug, "sssp: transformation",
+			slog.Int("original_vertices", len(s.tg.OriginalTo)),
+			slog.Int("vertices", s.tg.G.V),
+			slog.Int("edges", s.tg.G.NumEdges()))
+	}
+}
+
+// phaseChange reports a BMSSP phase to the listener and, if enabled, the log.
+func (s *Solver) phaseChange(phase string, level int) {
+	s.listener.OnPhaseChange(phase, level)
+	if s.logDebug {
+		s.logger.LogAttrs(context.Background(), slog.LevelDebug, "sssp: phase",
+			slog.String("phase", phase),
+			slog.Int("level", level),
+			slog.Int("depth", s.depth))
+	}
+}
+
+// finishLog logs the statistics of the run that just ended.
+func (s *Solver) finishLog() {
+	if !s.logDebug {
+		return
+	}
+	attrs := []slog.Attr{
+		slog.Int("settled", s.settled),
+		slog.Int("relaxations", s.relaxations),
+		slog.Int("max_depth", s.maxDepth),
+		slog.Any("level_counts", s.levelCounts),
+		slog.Bool("stopped_early", s.stopped),
+	}
+	if s.timing {
+		attrs = append(attrs, slog.Any("timings", s.timings))
+	}
+	s.logger.LogAttrs(context.Background(), slog.LevelDebug, "sssp: run finished", attrs...)
+}
@@ -0,0 +1,45 @@
+package sssp
+
+import (
+	"bytes"
+	"log/slog"
+	"strings"
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/gen"
+)
+
+func TestLogger(t *testing.T) {
+	g := gen.Random(500, 2000, 1)
+	tg := g.ToConstantDegree()
+	solver := NewSolver(tg.G)
+	solver.SetTransform(tg)
+
+	var buf bytes.Buffer
+	solver.SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	solver.Run(tg.StartNode(0))
+
+	out := buf.String()
+	for _, want := range []string{
+		`msg="sssp: run started" sources=[`,
+		`msg="sssp: transformation" original_vertices=500`,
+		`msg="sssp: phase" phase=FindPivots`,
+		`msg="sssp: phase" phase=BaseCase level=0`,
+		`msg="sssp: run finished" settled=`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("log is missing %q", want)
+		}
+	}
+	if n := strings.Count(out, "sssp: run finished"); n != 1 {
+		t.Errorf("run finished logged %d times, want 1", n)
+	}
+
+	// Above debug level nothing is logged
+	buf.Reset()
+	solver.SetLogger(slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelInfo})))
+	solver.Run(tg.StartNode(0))
+	if buf.Len() != 0 {
+		t.Errorf("info-level logger got %q", buf.String())
+	}
+}
@@ -0,0 +1,75 @@
+package sssp
+
+import (
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/gen"
+	"github.com/phr3nzy/duan-sssp/graph"
+)
+
+// multiSourceDijkstra solves from a virtual vertex with zero-weight edges to
+// every source.
+func multiSourceDijkstra(g *graph.Graph, sources []int) []float64 {
+	ext := graph.NewGraph(g.V + 1)
+	copy(ext.Adj, g.Adj)
+	for _, v := range sources {
+		ext.AddEdge(g.V, v, 0)
+	}
+	return Dijkstra(ext, g.V)[:g.V]
+}
+
+func TestRunMulti(t *testing.T) {
+	g := gen.Random(400, 1600, 9)
+	tg := g.ToConstantDegree()
+	solver := NewSolver(tg.G)
+
+	mapped := func(sources []int) []int {
+		res := make([]int, len(sources))
+		for i, v := range sources {
+			res[i] = tg.OriginalTo[v]
+		}
+		return res
+	}
+
+	want := multiSourceDijkstra(g, []int{3, 7, 250})
+	for _, sources := range [][]int{{3, 7, 250}, {3, 3, 7, 250, 7}, {250, 3, 7, 3}} {
+		dist, err := solver.RunMulti(mapped(sources))
+		if err != nil {
+			t.Fatalf("%v: %v", sources, err)
+		}
+		got := tg.MapDistances(dist)
+		for v := range want {
+			if got[v] != want[v] {
+				t.Fatalf("%v: dist[%d] = %v, want %v", sources, v, got[v], want[v])
+			}
+		}
+	}
+
+	// Each distinct source is initialized once
+	rec := &recordingListener{}
+	solver.SetEventListener(rec)
+	if _, err := solver.RunMulti(mapped([]int{3, 3, 7, 3})); err != nil {
+		t.Fatal(err)
+	}
+	roots := 0
+	for _, e := range rec.edges {
+		if e.from == -1 {
+			roots++
+		}
+	}
+	if roots != 2 {
+		t.Errorf("%d sources initialized, want 2", roots)
+	}
+}
+
+func TestRunMultiRejectsBadSources(t *testing.T) {
+	solver := NewSolver(graph.NewGraph(5))
+	for _, sources := range [][]int{nil, {1, 5}, {-1}, {2, 2, 9}} {
+		if _, err := solver.RunMulti(sources); err == nil {
+			t.Errorf("RunMulti(%v) succeeded", sources)
+		}
+	}
+	if dist, err := solver.RunMulti([]int{4, 4}); err != nil || dist[4] != 0 || dist[0] != Infinity {
+		t.Errorf("RunMulti([4 4]) = %v, %v", dist, err)
+	}
+}
@@ -0,0 +1,47 @@
+package sssp
+
+import (
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/graph"
+)
+
+func TestNewSolverEMalformed(t *testing.T) {
+	badEdge := graph.NewGraph(2)
+	badEdge.AddEdge(0, 2, 1)
+	negEdge := graph.NewGraph(2)
+	negEdge.AddEdge(1, -1, 1)
+
+	tests := []struct {
+		name string
+		g    *graph.Graph
+	}{
+		{"nil", nil},
+		{"zero value with V set", &graph.Graph{V: 3}},
+		{"short Adj", &graph.Graph{V: 3, Adj: make([][]graph.Edge, 2)}},
+		{"edge past V", badEdge},
+		{"negative edge target", negEdge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if s, err := NewSolverE(tt.g); err == nil {
+				t.Fatalf("expected error, got solver %p", s)
+			}
+			defer func() {
+				if recover() == nil {
+					t.Error("NewSolver did not panic")
+				}
+			}()
+			NewSolver(tt.g)
+		})
+	}
+}
+
+func TestNewSolverEValid(t *testing.T) {
+	for _, g := range []*graph.Graph{{}, graph.NewGraph(3)} {
+		if _, err := NewSolverE(g); err != nil {
+			t.Errorf("V=%d: unexpected error %v", g.V, err)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package sssp
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/graph"
+)
+
+// TestNearMaxWeights checks that sums reaching or overflowing Infinity do not
+// count as discoveries.
+func TestNearMaxWeights(t *testing.T) {
+	g := graph.NewGraph(6)
+	g.AddEdge(0, 1, math.MaxFloat64/2)
+	g.AddEdge(1, 2, math.MaxFloat64/2) // sums to exactly Infinity
+	g.AddEdge(0, 3, 0.9*math.MaxFloat64)
+	g.AddEdge(3, 4, 0.9*math.MaxFloat64) // overflows to +Inf
+	g.AddEdge(0, 5, 1)
+
+	tg := g.ToConstantDegree()
+	solver := NewSolver(tg.G)
+	rec := &recordingListener{}
+	solver.SetEventListener(rec)
+	dist := tg.MapDistances(solver.Run(tg.OriginalTo[0]))
+
+	want := []float64{0, math.MaxFloat64 / 2, Infinity, 0.9 * math.MaxFloat64, Infinity, 1}
+	for v := range want {
+		if dist[v] != want[v] {
+			t.Errorf("dist[%d] = %v, want %v", v, dist[v], want[v])
+		}
+	}
+
+	for _, e := range rec.edges {
+		if e.dist >= Infinity {
+			t.Errorf("event %d->%d reported distance %v", e.from, e.to, e.dist)
+		}
+	}
+}
+
+// TestInjectedPlusInf plants math.Inf(1) where the solver normally keeps
+// Infinity and checks it is still treated as unreachable.
+func TestInjectedPlusInf(t *testing.T) {
+	inf := math.Inf(1)
+
+	g := graph.NewGraph(4)
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(3, 2, 1)
+	solver := NewSolver(g)
+	rec := &relaxRecorder{}
+	solver.SetEventListener(rec)
+	for i := range solver.Dist {
+		solver.Dist[i] = inf
+	}
+	solver.Dist[0] = 0
+
+	// 3 is in S but at +Inf, so it must not be expanded
+	solver.BaseCase(Infinity, []int{0, 3})
+	if want := []float64{0, 1, 2, inf}; !slices.Equal(solver.Dist, want) {
+		t.Errorf("dist = %v, want %v", solver.Dist, want)
+	}
+	if rec.discovered != 2 || rec.relaxed != 0 {
+		t.Errorf("%d discoveries and %d relaxations, want first discoveries of 1 and 2 only", rec.discovered, rec.relaxed)
+	}
+
+	dist := []float64{0, inf, 3, Infinity}
+	if got := solver.ReachableCount(dist); got != 2 {
+		t.Errorf("ReachableCount = %d, want 2", got)
+	}
+	if got := solver.UnreachableVertices(dist); !slices.Equal(got, []int{1, 3}) {
+		t.Errorf("UnreachableVertices = %v, want [1 3]", got)
+	}
+	if got := ClosestN(dist, 4); len(got) != 2 {
+		t.Errorf("ClosestN = %v, want vertices 0 and 2", got)
+	}
+	if err := Verify(graph.NewGraph(4), 0, []float64{0, inf, Infinity, inf}); err != nil {
+		t.Errorf("Verify: %v", err)
+	}
+
+	tg := &graph.TransformedGraph{OriginalTo: []int{0, 1}}
+	if got := tg.MapDistances([]float64{0, inf}); got[1] != Infinity {
+		t.Errorf("MapDistances kept %v, want Infinity", got[1])
+	}
+}
+
+type relaxRecorder struct {
+	NoOpListener
+	discovered, relaxed int
+}
+
+func (r *relaxRecorder) OnNodeDiscovered(from, to int, dist float64)          { r.discovered++ }
+func (r *relaxRecorder) OnNodeRelaxed(from, to int, oldDist, newDist float64) { r.relaxed++ }
@@ -0,0 +1,36 @@
+package sssp
+
+import (
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/gen"
+)
+
+func TestProgressMonotonic(t *testing.T) {
+	// Seeded so that the source reaches most of the graph
+	g := gen.Random(2000, 6000, 1)
+	tg := g.ToConstantDegree()
+	solver := NewSolver(tg.G)
+
+	var reports []int
+	solver.SetProgress(func(settled, total int) {
+		if total != tg.G.V {
+			t.Fatalf("total = %d, want %d", total, tg.G.V)
+		}
+		reports = append(reports, settled)
+	})
+	dist := solver.Run(tg.OriginalTo[0])
+
+	if len(reports) < 2 {
+		t.Fatalf("only %d progress reports", len(reports))
+	}
+	for i := 1; i < len(reports); i++ {
+		if reports[i] < reports[i-1] {
+			t.Fatalf("report %d: settled went from %d to %d", i, reports[i-1], reports[i])
+		}
+	}
+
+	if got, want := reports[len(reports)-1], solver.ReachableCount(dist); got != want {
+		t.Errorf("final settled = %d, want %d reachable", got, want)
+	}
+}
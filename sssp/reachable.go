@@ -0,0 +1,71 @@
+package sssp
+
+import "github.com/phr3nzy/duan-sssp/graph"
+
+// ReachableCount returns how many entries of dist are finite. dist is
+// usually the mapped (original-vertex) result of Run.
+func (s *Solver) ReachableCount(dist []float64) int {
+	count := 0
+	for _, d := range dist {
+		if !isUnreachable(d) {
+			count++
+		}
+	}
+	return count
+}
+
+// UnreachableVertices returns, in increasing order, the vertices whose
+// distance in dist is Infinity.
+func (s *Solver) UnreachableVertices(dist []float64) []int {
+	var res []int
+	for v, d := range dist {
+		if isUnreachable(d) {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// RunWithReachable runs from source and returns the distances with a
+// parallel slice reporting which are finite. With a transform set (see
+// SetTransform) source is an original vertex and both slices are indexed by
+// original vertices; otherwise both are over G. The distance slice is
+// s.Dist only in the latter case.
+func (s *Solver) RunWithReachable(source int) ([]float64, []bool) {
+	var dist []float64
+	if s.tg != nil {
+		dist = s.tg.MapDistances(s.Run(s.tg.StartNode(source)))
+	} else {
+		dist = s.Run(source)
+	}
+
+	reachable := make([]bool, len(dist))
+	for v, d := range dist {
+		reachable[v] = !isUnreachable(d)
+	}
+	return dist, reachable
+}
+
+// Reachability returns the minimum number of edges from source to each
+// vertex of g, or -1 where there is no path. Weights are ignored, so this is
+// a plain O(V+E) breadth-first search.
+func Reachability(g *graph.Graph, source int) []int {
+	hops := make([]int, g.V)
+	for i := range hops {
+		hops[i] = -1
+	}
+	hops[source] = 0
+
+	// queue entries before i have been expanded
+	queue := []int{source}
+	for i := 0; i < len(queue); i++ {
+		u := queue[i]
+		for _, e := range g.Adj[u] {
+			if hops[e.To] == -1 {
+				hops[e.To] = hops[u] + 1
+				queue = append(queue, e.To)
+			}
+		}
+	}
+	return hops
+}
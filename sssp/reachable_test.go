@@ -0,0 +1,125 @@
+package sssp
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/graph"
+)
+
+func TestReachability(t *testing.T) {
+	// Two islands: a cycle over 0..3 and a path over 4..6
+	g := graph.NewGraph(7)
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 2)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(3, 0, 4)
+	g.AddEdge(4, 5, 1)
+	g.AddEdge(5, 6, 1)
+
+	tg := g.ToConstantDegree()
+	solver := NewSolver(tg.G)
+
+	dist := tg.MapDistances(solver.Run(tg.OriginalTo[1]))
+	if got := solver.ReachableCount(dist); got != 4 {
+		t.Errorf("from 1: ReachableCount = %d, want 4", got)
+	}
+	if got := solver.UnreachableVertices(dist); !slices.Equal(got, []int{4, 5, 6}) {
+		t.Errorf("from 1: UnreachableVertices = %v, want [4 5 6]", got)
+	}
+
+	dist = tg.MapDistances(solver.Run(tg.OriginalTo[5]))
+	if got := solver.ReachableCount(dist); got != 2 {
+		t.Errorf("from 5: ReachableCount = %d, want 2", got)
+	}
+	if got := solver.UnreachableVertices(dist); !slices.Equal(got, []int{0, 1, 2, 3, 4}) {
+		t.Errorf("from 5: UnreachableVertices = %v, want [0 1 2 3 4]", got)
+	}
+}
+
+func TestRunWithReachable(t *testing.T) {
+	// 0 -> 1 -> 2 and a separate 3 -> 4, with 5 isolated
+	g := graph.NewGraph(6)
+	g.AddEdge(0, 1, 2)
+	g.AddEdge(1, 2, 0)
+	g.AddEdge(3, 4, 1)
+
+	tg := g.ToConstantDegree()
+	mapped := NewSolver(tg.G)
+	mapped.SetTransform(tg)
+	dist, reachable := mapped.RunWithReachable(0)
+	if !slices.Equal(dist, []float64{0, 2, 2, Infinity, Infinity, Infinity}) {
+		t.Errorf("dist = %v", dist)
+	}
+	if !slices.Equal(reachable, []bool{true, true, true, false, false, false}) {
+		t.Errorf("reachable = %v", reachable)
+	}
+
+	raw := NewSolver(tg.G)
+	dist, reachable = raw.RunWithReachable(tg.OriginalTo[3])
+	if len(dist) != tg.G.V || len(reachable) != tg.G.V {
+		t.Fatalf("untransformed lengths %d, %d, want %d", len(dist), len(reachable), tg.G.V)
+	}
+	for x := range dist {
+		if reachable[x] != (dist[x] < Infinity) {
+			t.Errorf("node %d: reachable %v with distance %v", x, reachable[x], dist[x])
+		}
+		if want := tg.NewToOrigin[x] == 3 || tg.NewToOrigin[x] == 4; reachable[x] != want {
+			t.Errorf("node %d of vertex %d: reachable %v", x, tg.NewToOrigin[x], reachable[x])
+		}
+	}
+}
+
+func TestReachabilityHops(t *testing.T) {
+	// 0 -> 1 -> 2 -> 3 with a heavy shortcut 0 -> 3, a back edge 3 -> 1,
+	// and 4 only reaching 0.
+	g := graph.NewGraph(5)
+	g.AddEdge(0, 1, 1)
+	g.AddEdge(1, 2, 1)
+	g.AddEdge(2, 3, 1)
+	g.AddEdge(0, 3, 100)
+	g.AddEdge(3, 1, 1)
+	g.AddEdge(4, 0, 1)
+
+	cases := []struct {
+		source int
+		want   []int
+	}{
+		{0, []int{0, 1, 2, 1, -1}},
+		{2, []int{-1, 2, 0, 1, -1}},
+		{4, []int{1, 2, 3, 2, 0}},
+	}
+	for _, c := range cases {
+		if got := Reachability(g, c.source); !slices.Equal(got, c.want) {
+			t.Errorf("from %d: Reachability = %v, want %v", c.source, got, c.want)
+		}
+	}
+}
+
+// TestUnitWeightMatchesBFS uses Reachability as an oracle independent of
+// Dijkstra: with every edge weighing w, distances are hop counts times w.
+func TestUnitWeightMatchesBFS(t *testing.T) {
+	for seed := int64(0); seed < 30; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		n := 1 + rng.Intn(400)
+		w := []float64{1, 1, 2.5}[seed%3]
+		g := graph.NewGraph(n)
+		for i := 0; i < n*rng.Intn(4); i++ {
+			g.AddEdge(rng.Intn(n), rng.Intn(n), w)
+		}
+		source := rng.Intn(n)
+
+		hops := Reachability(g, source)
+		dist := ShortestPaths(g, source)
+		for v := range dist {
+			want := Infinity
+			if hops[v] >= 0 {
+				want = float64(hops[v]) * w
+			}
+			if dist[v] != want {
+				t.Fatalf("seed %d (n=%d, w=%v): dist[%d] = %v, want %v (%d hops)", seed, n, w, v, dist[v], want, hops[v])
+			}
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package sssp
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/gen"
+	"github.com/phr3nzy/duan-sssp/graph"
+)
+
+// smallComponentGraph has 200K vertices but only the first 100 are connected,
+// so a run from vertex 0 touches a tiny part of the graph.
+func smallComponentGraph() *graph.TransformedGraph {
+	rng := rand.New(rand.NewSource(1))
+	g := graph.NewGraph(200000)
+	for i := 0; i < 300; i++ {
+		g.AddEdge(rng.Intn(100), rng.Intn(100), rng.Float64()*10)
+	}
+	return g.ToConstantDegree()
+}
+
+// BenchmarkSmallRuns measures per-call overhead when each run reaches only a
+// few vertices of a large graph.
+func BenchmarkSmallRuns(b *testing.B) {
+	tg := smallComponentGraph()
+	solver := NewSolver(tg.G)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		solver.Run(tg.OriginalTo[i%100])
+	}
+}
+
+// TestRepeatedRunsMatchFreshSolver checks that state left by one run, including
+// one through RunInto, never leaks into the next.
+func TestRepeatedRunsMatchFreshSolver(t *testing.T) {
+	tg := gen.Random(300, 900, 7).ToConstantDegree()
+	reused := NewSolver(tg.G)
+	out := make([]float64, tg.G.V)
+
+	for i, src := range []int{0, 5, 0, 120, 299, 5} {
+		source := tg.OriginalTo[src]
+		var got []float64
+		if i == 3 {
+			if err := reused.RunInto(source, out); err != nil {
+				t.Fatal(err)
+			}
+			got = out
+		} else {
+			got = reused.Run(source)
+		}
+
+		fresh := NewSolver(tg.G)
+		want := fresh.Run(source)
+		for v := range want {
+			if got[v] != want[v] {
+				t.Fatalf("run %d from %d: dist[%d] = %v, want %v", i, src, v, got[v], want[v])
+			}
+			if i != 3 && reused.Pred[v] != fresh.Pred[v] {
+				t.Fatalf("run %d from %d: pred[%d] = %d, want %d", i, src, v, reused.Pred[v], fresh.Pred[v])
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package sssp
+
+import (
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/gen"
+)
+
+func TestRunInto(t *testing.T) {
+	g := gen.Random(500, 1500, 1)
+	tg := g.ToConstantDegree()
+	solver := NewSolver(tg.G)
+	source := tg.OriginalTo[0]
+
+	want := append([]float64(nil), solver.Run(source)...)
+	before := append([]float64(nil), solver.Dist...)
+
+	out := make([]float64, tg.G.V+5)
+	if err := solver.RunInto(source, out); err != nil {
+		t.Fatalf("RunInto: %v", err)
+	}
+	for v := range want {
+		if out[v] != want[v] {
+			t.Fatalf("out[%d] = %v, want %v", v, out[v], want[v])
+		}
+	}
+	for v := range before {
+		if solver.Dist[v] != before[v] {
+			t.Fatalf("RunInto modified solver.Dist[%d]", v)
+		}
+	}
+
+	if err := solver.RunInto(source, make([]float64, tg.G.V-1)); err == nil {
+		t.Error("expected error for short output slice")
+	}
+}
+
+// TestRunIntoAllocs checks that repeated RunInto calls into the same buffer
+// allocate nothing once the first call has grown the solver's buffers.
+func TestRunIntoAllocs(t *testing.T) {
+	g := gen.Random(1000, 3000, 1)
+	tg := g.ToConstantDegree()
+	solver := NewSolver(tg.G)
+	solver.SetWorkers(1)
+	out := make([]float64, tg.G.V)
+	source := tg.StartNode(0)
+	solver.RunInto(source, out)
+
+	if n := testing.AllocsPerRun(20, func() { solver.RunInto(source, out) }); n != 0 {
+		t.Errorf("RunInto allocated %v times per call, want 0", n)
+	}
+
+	want := Dijkstra(tg.G, source)
+	for v := range want {
+		if out[v] != want[v] {
+			t.Fatalf("out[%d] = %v, want %v", v, out[v], want[v])
+		}
+	}
+}
+
+// BenchmarkRunInto reuses one caller-owned buffer across runs. After the
+// warm-up call it should report 0 allocs/op.
+func BenchmarkRunInto(b *testing.B) {
+	g := gen.Random(1000, 3000, 1)
+	tg := g.ToConstantDegree()
+	solver := NewSolver(tg.G)
+	solver.SetWorkers(1)
+	out := make([]float64, tg.G.V)
+	solver.RunInto(tg.StartNode(0), out)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		solver.RunInto(tg.StartNode(0), out)
+	}
+}
@@ -0,0 +1,26 @@
+package sssp
+
+import "github.com/phr3nzy/duan-sssp/graph"
+
+// ShortestPaths runs the full pipeline on g: it transforms g to constant
+// degree, solves from source and maps the distances back. The result is
+// indexed by g's vertices; unreachable vertices are Infinity.
+//
+// Use NewTransformedSolver to reuse the transform and the solver across
+// several sources.
+func ShortestPaths(g *graph.Graph, source int) []float64 {
+	return NewTransformedSolver(g.ToConstantDegree()).Solve(source)
+}
+
+// DistancesTo returns, for every vertex of g, its shortest distance to
+// target: ShortestPaths on the reversed graph, solved from target. Vertices
+// that cannot reach target are Infinity.
+func DistancesTo(g *graph.Graph, target int) []float64 {
+	return ShortestPaths(g.Reverse(), target)
+}
+
+// Distance returns the distance from source to target in g and whether target
+// is reachable. See TransformedSolver.Distance.
+func Distance(g *graph.Graph, source, target int) (float64, bool) {
+	return NewTransformedSolver(g.ToConstantDegree()).Distance(source, target)
+}
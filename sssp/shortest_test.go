@@ -0,0 +1,112 @@
+package sssp
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/gen"
+	"github.com/phr3nzy/duan-sssp/graph"
+)
+
+func TestShortestPathsMatchesDijkstra(t *testing.T) {
+	for seed := int64(0); seed < 50; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		vertices := 2 + rng.Intn(300)
+		g := gen.Random(vertices, vertices*(1+rng.Intn(6)), seed)
+		source := rng.Intn(vertices)
+
+		got := ShortestPaths(g, source)
+		want := Dijkstra(g, source)
+		if len(got) != g.V {
+			t.Fatalf("seed %d: len = %d, want %d", seed, len(got), g.V)
+		}
+		for v := range want {
+			if got[v] != want[v] {
+				t.Fatalf("seed %d: dist[%d] = %v, want %v", seed, v, got[v], want[v])
+			}
+		}
+	}
+}
+
+func TestDistanceMatchesShortestPaths(t *testing.T) {
+	for seed := int64(0); seed < 10; seed++ {
+		g := gen.Random(200, 300+int(seed)*50, seed)
+		rng := rand.New(rand.NewSource(seed))
+		source := rng.Intn(g.V)
+		all := ShortestPaths(g, source)
+
+		for i := 0; i < 30; i++ {
+			target := rng.Intn(g.V)
+			d, ok := Distance(g, source, target)
+			if d != all[target] {
+				t.Fatalf("seed %d: Distance(%d, %d) = %v, want %v", seed, source, target, d, all[target])
+			}
+			if ok != (all[target] < Infinity) {
+				t.Fatalf("seed %d: Distance(%d, %d) reachable = %v for dist %v", seed, source, target, ok, all[target])
+			}
+		}
+	}
+}
+
+func TestDistanceOutOfRange(t *testing.T) {
+	g := gen.Random(20, 60, 1)
+	for _, pair := range [][2]int{{-1, 0}, {0, -1}, {20, 0}, {0, 20}} {
+		if d, ok := Distance(g, pair[0], pair[1]); ok || d != Infinity {
+			t.Errorf("Distance(%d, %d) = %v, %v, want Infinity, false", pair[0], pair[1], d, ok)
+		}
+	}
+}
+
+// TestDistanceStopsEarly checks that a query for a neighbour of the source
+// settles only a fraction of a 40x40 grid.
+func TestDistanceStopsEarly(t *testing.T) {
+	g, index := graph.NewGrid(40, 40, false, func(x, y int) float64 { return 1 })
+	ts := NewTransformedSolver(g.ToConstantDegree())
+	settled := 0
+	ts.SetProgress(func(n, total int) { settled = n })
+
+	source, target := index(20, 20), index(21, 20)
+	d, ok := ts.Distance(source, target)
+	if !ok || d != 1 {
+		t.Fatalf("Distance = %v, %v, want 1, true", d, ok)
+	}
+	partial := settled
+
+	ts.Solve(source)
+	if partial*4 > settled {
+		t.Errorf("Distance settled %d nodes, want well under the %d of a full run", partial, settled)
+	}
+}
+
+// TestDistancesToMatchesShortestPaths checks DistancesTo(t)[u] against
+// ShortestPaths(u)[t]. The two sum the same path in opposite orders, so
+// finite distances may differ by rounding.
+func TestDistancesToMatchesShortestPaths(t *testing.T) {
+	for seed := int64(0); seed < 10; seed++ {
+		g := gen.Random(150, 250+int(seed)*40, seed)
+		rng := rand.New(rand.NewSource(seed))
+
+		for i := 0; i < 5; i++ {
+			target := rng.Intn(g.V)
+			to := DistancesTo(g, target)
+			if len(to) != g.V {
+				t.Fatalf("seed %d: len = %d, want %d", seed, len(to), g.V)
+			}
+			for j := 0; j < 20; j++ {
+				u := rng.Intn(g.V)
+				want := ShortestPaths(g, u)[target]
+				got := to[u]
+				if isUnreachable(want) || isUnreachable(got) {
+					if isUnreachable(want) != isUnreachable(got) {
+						t.Fatalf("seed %d: DistancesTo(%d)[%d] = %v, want %v", seed, target, u, got, want)
+					}
+					continue
+				}
+				if math.Abs(got-want) > 1e-9*max(1, want) {
+					t.Fatalf("seed %d: DistancesTo(%d)[%d] = %v, want %v", seed, target, u, got, want)
+				}
+			}
+		}
+	}
+}
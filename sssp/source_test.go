@@ -0,0 +1,50 @@
+package sssp
+
+import (
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/graph"
+)
+
+// TestZeroOutDegreeSource covers sources the transform wraps into a cycle
+// with no way out: an isolated vertex, one with only incoming edges, and the
+// only vertex of a single-vertex graph.
+func TestZeroOutDegreeSource(t *testing.T) {
+	withIncoming := graph.NewGraph(4)
+	withIncoming.AddEdge(0, 3, 1)
+	withIncoming.AddEdge(1, 3, 2)
+	withIncoming.AddEdge(0, 1, 5)
+	withIncoming.AddEdge(1, 2, 1)
+
+	isolated := graph.NewGraph(4)
+	isolated.AddEdge(0, 1, 1)
+	isolated.AddEdge(1, 2, 1)
+
+	tests := []struct {
+		name   string
+		g      *graph.Graph
+		source int
+	}{
+		{"isolated", isolated, 3},
+		{"incoming only", withIncoming, 3},
+		{"single vertex", graph.NewGraph(1), 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tg := tt.g.ToConstantDegree()
+			solver := NewSolver(tg.G)
+			dist := tg.MapDistances(solver.Run(tg.OriginalTo[tt.source]))
+
+			for v, d := range dist {
+				want := Infinity
+				if v == tt.source {
+					want = 0
+				}
+				if d != want {
+					t.Errorf("dist[%d] = %v, want %v", v, d, want)
+				}
+			}
+		})
+	}
+}
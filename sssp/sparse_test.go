@@ -0,0 +1,56 @@
+package sssp
+
+import (
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/graph"
+)
+
+func TestRunSparse(t *testing.T) {
+	// A five-vertex component with a branch and a cycle in a graph of 5000
+	g := graph.NewGraph(5000)
+	g.AddEdge(10, 11, 2)
+	g.AddEdge(11, 12, 1)
+	g.AddEdge(10, 12, 5)
+	g.AddEdge(12, 10, 1)
+	g.AddEdge(12, 4000, 3)
+	g.AddEdge(4000, 4001, 0)
+	for v := 100; v < 4000; v++ {
+		g.AddEdge(v, v+1, 1) // unreachable from 10
+	}
+
+	tg := g.ToConstantDegree()
+	solver := NewSolver(tg.G)
+	source := tg.OriginalTo[10]
+
+	sparse := solver.RunSparse(source)
+	dense := solver.Run(source)
+
+	var want []VertexDist
+	for v, d := range dense {
+		if d < Infinity {
+			want = append(want, VertexDist{v, d})
+		}
+	}
+	if len(sparse) != len(want) {
+		t.Fatalf("RunSparse returned %d vertices, dense run reaches %d", len(sparse), len(want))
+	}
+	for i := range want {
+		if sparse[i] != want[i] {
+			t.Fatalf("entry %d = %+v, want %+v", i, sparse[i], want[i])
+		}
+	}
+
+	got := make(map[int]float64)
+	for _, e := range sparse {
+		got[tg.NewToOrigin[e.Vertex]] = e.Dist
+	}
+	for v, d := range map[int]float64{10: 0, 11: 2, 12: 3, 4000: 6, 4001: 6} {
+		if got[v] != d {
+			t.Errorf("original vertex %d: %v, want %v", v, got[v], d)
+		}
+	}
+	if len(got) != 5 {
+		t.Errorf("reached %d original vertices, want 5", len(got))
+	}
+}
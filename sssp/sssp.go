@@ -2,9 +2,13 @@ package sssp
 
 import (
 	"container/heap"
+	"fmt"
+	"log/slog"
 	"math"
 	"runtime"
+	"slices"
 	"sync"
+	"time"
 
 	"github.com/phr3nzy/duan-sssp/ds"
 	"github.com/phr3nzy/duan-sssp/graph"
@@ -13,10 +17,18 @@ import (
 // Algorithm Constants
 const Infinity = math.MaxFloat64
 
+// isUnreachable reports whether d means "no path": Infinity, or +Inf should
+// an overflowing sum ever be stored.
+func isUnreachable(d float64) bool {
+	return d >= Infinity
+}
+
 // DistMap holds current distance estimates.
 type DistMap []float64
 
-// PriorityQueue for BaseCase
+// PriorityQueue for BaseCase, Dijkstra and A*. Equal priorities pop in
+// increasing vertex order, so the settle order does not depend on the order
+// entries were pushed in.
 type PQItem struct {
 	u        int
 	priority float64
@@ -24,9 +36,14 @@ type PQItem struct {
 }
 type PriorityQueue []*PQItem
 
-func (pq PriorityQueue) Len() int           { return len(pq) }
-func (pq PriorityQueue) Less(i, j int) bool { return pq[i].priority < pq[j].priority }
-func (pq PriorityQueue) Swap(i, j int)      { pq[i], pq[j] = pq[j], pq[i]; pq[i].index = i; pq[j].index = j }
+func (pq PriorityQueue) Len() int { return len(pq) }
+func (pq PriorityQueue) Less(i, j int) bool {
+	if pq[i].priority != pq[j].priority {
+		return pq[i].priority < pq[j].priority
+	}
+	return pq[i].u < pq[j].u
+}
+func (pq PriorityQueue) Swap(i, j int) { pq[i], pq[j] = pq[j], pq[i]; pq[i].index = i; pq[j].index = j }
 func (pq *PriorityQueue) Push(x interface{}) {
 	item := x.(*PQItem)
 	item.index = len(*pq)
@@ -42,17 +59,66 @@ func (pq *PriorityQueue) Pop() interface{} {
 	return item
 }
 
+// getPQItem takes a BaseCase heap entry from the solver's free list,
+// allocating only when it is empty.
+func (s *Solver) getPQItem(u int, priority float64) *PQItem {
+	var item *PQItem
+	if n := len(s.pqFree); n > 0 {
+		item = s.pqFree[n-1]
+		s.pqFree = s.pqFree[:n-1]
+	} else {
+		item = &PQItem{}
+	}
+	item.u = u
+	item.priority = priority
+	return item
+}
+
+// putPQItem returns a heap entry to the free list.
+func (s *Solver) putPQItem(item *PQItem) {
+	item.index = -1
+	s.pqFree = append(s.pqFree, item)
+}
+
 // Solver encapsulates the algorithm state.
 type Solver struct {
 	G    *graph.Graph
 	Dist DistMap
+	Pred []int // Predecessor on a shortest path, -1 for the source and unreached vertices
 	K    int
 	T    int
 
-	// Pre-allocated buffers for performance
-	bufInt   []int
-	bufItem  []ds.Item
+	// Epsilon is the relative tolerance FindPivots uses to decide that an
+	// edge u->v is tight, i.e. |Dist[v] - (Dist[u]+w)| <= Epsilon *
+	// max(1, Dist[u]+w). NewSolver sets it to 1e-9; 0 requires exact equality.
+	Epsilon float64
+
+	// NewFrontier, if set, builds the data structure of each BMSSP call
+	// with block size m and bound B, in place of a reused ds.DataStructure.
+	// It lets tests cross-check BMSSP against another Frontier.
+	NewFrontier func(m int, B float64) Frontier
+
+	// BaseCaseLimit is how many vertices BaseCase settles before returning
+	// a smaller bound. NewSolver sets it to K+1; it must be at least 1.
+	BaseCaseLimit int
+
+	// Pre-allocated buffers for performance. A run reuses them all, so once
+	// they have grown it allocates nothing on the sequential path.
+	bufInt   []int     // the single source of Run and its variants
+	bufItem  []ds.Item // K, the items relaxEdges returns for BatchPrepend
 	bufBatch []ds.Item
+	pq       PriorityQueue // BaseCase heap, emptied before each call returns
+	pqFree   []*PQItem     // heap entries not in use
+	layers   [2][]int      // relaxKSteps' current and previous layer
+
+	// Result buffers by BMSSP recursion depth, see atDepth
+	scratch []*depthScratch
+
+	// FindPivots scratch space, sized to G.V and cleared after each call
+	inW        []bool
+	treeSize   []int
+	layerMark  []int
+	layerStamp int
 
 	// Parallel processing
 	workerPool chan struct{}
@@ -60,11 +126,102 @@ type Solver struct {
 
 	// Event listener for visualization
 	listener EventListener
+
+	// BMSSP recursion depth of the current call and the deepest seen this run
+	depth    int
+	maxDepth int
+
+	// Vertices whose Dist, Pred or settledMark the current run has written.
+	// gen[v] == curGen marks membership so the next run can reset just these.
+	gen       []uint32
+	curGen    uint32
+	touched   []int
+	fullReset bool
+
+	// The Dist slice the touched list was recorded in, see RunInto
+	touchedDist []float64
+
+	// Relaxations accepted this run, including equal-distance ones
+	relaxations int
+
+	// Vertices first added to a U set at each BMSSP level this run
+	levelCounts []int
+	blockSizes  []int
+	levelMark   []bool
+
+	// U sets by BMSSP level. Recursion only descends one level at a time, so
+	// at most one call per level is active and each can reuse its set.
+	sets []*vertexSet
+
+	// Data structures by BMSSP level, reused the same way as sets
+	frontiers []*ds.DataStructure
+
+	// Early stop, see RunNearest: onSettle sees each vertex the first time
+	// it is settled, and once it returns true stopped unwinds the recursion.
+	onSettle func(u int) bool
+	stopped  bool
+
+	// Distinct vertices settled this run, for progress reporting
+	settledMark  []bool
+	settled      int
+	progress     func(settled, total int)
+	nextProgress int
+
+	// Per-phase wall-clock timing, see EnableTiming
+	timing     bool
+	timings    PhaseTimings
+	phase      *time.Duration
+	phaseStart time.Time
+	runStart   time.Time
+
+	// Structured logging, see SetLogger; logDebug is fixed for each run
+	logger   *slog.Logger
+	logDebug bool
+
+	// Optional mapping back to the original graph, see SetTransform
+	tg *graph.TransformedGraph
 }
 
+// NewSolver creates a solver for g. It panics if g is malformed; use
+// NewSolverE to get an error instead.
 func NewSolver(g *graph.Graph) *Solver {
+	s, err := NewSolverE(g)
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+// NewSolverSequential is NewSolver with SetWorkers(1): relaxation always
+// runs on the calling goroutine and no worker goroutines are started.
+func NewSolverSequential(g *graph.Graph) *Solver {
+	s := NewSolver(g)
+	s.SetWorkers(1)
+	return s
+}
+
+// NewSolverE creates a solver for g, or returns an error if g is nil, its
+// adjacency list does not have g.V entries, or an edge points outside [0, V).
+func NewSolverE(g *graph.Graph) (*Solver, error) {
+	if g == nil {
+		return nil, fmt.Errorf("sssp: nil graph")
+	}
+	if len(g.Adj) != g.V {
+		return nil, fmt.Errorf("sssp: graph has V = %d but %d adjacency lists", g.V, len(g.Adj))
+	}
+	for u, adj := range g.Adj {
+		for _, e := range adj {
+			if e.To < 0 || e.To >= g.V {
+				return nil, fmt.Errorf("sssp: edge %d->%d out of range [0,%d)", u, e.To, g.V)
+			}
+		}
+	}
+
+	// The formulas give k < 2 below 256 vertices and t < 2 below 7 (both are
+	// 0 for a single vertex), so both are clamped to 2. Graphs of up to four
+	// vertices then use at most one level above BaseCase; see topLevel.
 	n := float64(g.V)
-	logN := math.Log(n)
+	logN := math.Log2(n)
 	// k = floor(log^(1/3) n)
 	k := int(math.Floor(math.Pow(logN, 1.0/3.0)))
 	if k < 2 {
@@ -83,17 +240,45 @@ func NewSolver(g *graph.Graph) *Solver {
 	}
 
 	return &Solver{
-		G:          g,
-		Dist:       make(DistMap, g.V),
-		K:          k,
-		T:          t,
-		bufInt:     make([]int, 0, 1000),
-		bufItem:    make([]ds.Item, 0, 1000),
-		bufBatch:   make([]ds.Item, 0, 1000),
-		workerPool: make(chan struct{}, numWorkers),
-		numWorkers: numWorkers,
-		listener:   &NoOpListener{},
+		G:             g,
+		Dist:          make(DistMap, g.V),
+		Pred:          make([]int, g.V),
+		K:             k,
+		T:             t,
+		BaseCaseLimit: k + 1,
+		Epsilon:       1e-9,
+		bufInt:        make([]int, 0, 1000),
+		bufItem:       make([]ds.Item, 0, 1000),
+		bufBatch:      make([]ds.Item, 0, 1000),
+		inW:           make([]bool, g.V),
+		treeSize:      make([]int, g.V),
+		layerMark:     make([]int, g.V),
+		settledMark:   make([]bool, g.V),
+		levelMark:     make([]bool, g.V),
+		gen:           make([]uint32, g.V),
+		fullReset:     true,
+		workerPool:    make(chan struct{}, numWorkers),
+		numWorkers:    numWorkers,
+		listener:      &NoOpListener{},
+	}, nil
+}
+
+// SetWorkers sets how many goroutines may relax edges at once. n < 1 selects
+// runtime.NumCPU(); n == 1 always relaxes sequentially.
+func (s *Solver) SetWorkers(n int) {
+	if n < 1 {
+		n = runtime.NumCPU()
 	}
+	s.numWorkers = n
+	s.workerPool = make(chan struct{}, n)
+}
+
+// SetProgress registers fn to be called with the number of distinct settled
+// vertices out of G.V, about every 1% and once when Run finishes. Unreachable
+// vertices are never settled. fn is called from the goroutine running Run;
+// nil disables reporting.
+func (s *Solver) SetProgress(fn func(settled, total int)) {
+	s.progress = fn
 }
 
 // SetEventListener sets the event listener for visualization
@@ -105,56 +290,239 @@ func (s *Solver) SetEventListener(listener EventListener) {
 	}
 }
 
+// Run computes distances from source and returns s.Dist. Unreached vertices
+// are Infinity; in particular a source with no outgoing edges yields 0 for
+// itself (and its zero-weight cycle after ToConstantDegree) and Infinity
+// everywhere else.
 func (s *Solver) Run(source int) []float64 {
-	for i := range s.Dist {
-		s.Dist[i] = Infinity
+	return s.run(s.one(source), Infinity)
+}
+
+// RunMulti computes, for every vertex, the distance to its nearest source,
+// as if a virtual vertex had zero-weight edges to each of sources. Repeated
+// sources are solved once. It returns an error, leaving s.Dist unchanged, if
+// sources is empty or holds a vertex outside [0, V).
+func (s *Solver) RunMulti(sources []int) ([]float64, error) {
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("sssp: no sources")
+	}
+	for _, v := range sources {
+		if v < 0 || v >= s.G.V {
+			return nil, fmt.Errorf("sssp: source %d out of range [0,%d)", v, s.G.V)
+		}
 	}
-	s.Dist[source] = 0
-	s.listener.OnNodeDiscovered(source, 0)
 
-	// Calculate Max Level l = ceil(log n / t)
-	n := float64(s.G.V)
-	l := int(math.Ceil(math.Log(n) / float64(s.T)))
+	S := slices.Clone(sources)
+	slices.Sort(S)
+	return s.run(slices.Compact(S), Infinity), nil
+}
 
-	// Initial call
-	// S = {source}, B = Infinity
-	S := []int{source}
-	s.listener.OnPhaseChange("BMSSP", l)
-	s.BMSSP(l, Infinity, S)
+// RunUntransformed is Run for a solver built directly on an original graph,
+// skipping ToConstantDegree. BMSSP does not rely on bounded degree for
+// correctness, so the distances are exact; what weakens is the running-time
+// bound, since relaxing a vertex of degree d costs O(d) instead of O(1) and
+// FindPivots' W sets can grow by up to a factor of the maximum degree per
+// step. Prefer it for graphs whose degree is already small.
+//
+// Unlike Run, it asserts that G is an original graph: it panics if
+// SetTransform has declared G to be the output of ToConstantDegree.
+func (s *Solver) RunUntransformed(source int) []float64 {
+	if s.tg != nil {
+		panic("sssp: RunUntransformed on a solver with a transform set; use Run")
+	}
+	return s.run(s.one(source), Infinity)
+}
+
+// RunSparse is Run returning only the reachable vertices, sorted by vertex.
+// The solve still uses the dense s.Dist; the result is built from the run's
+// touched list, so it costs O(r log r) for r reachable vertices, not O(V).
+func (s *Solver) RunSparse(source int) []VertexDist {
+	s.run(s.one(source), Infinity)
+
+	res := make([]VertexDist, 0, len(s.touched))
+	for _, v := range s.touched {
+		if d := s.Dist[v]; !isUnreachable(d) {
+			res = append(res, VertexDist{Vertex: v, Dist: d})
+		}
+	}
+	slices.SortFunc(res, func(a, b VertexDist) int { return a.Vertex - b.Vertex })
+	return res
+}
 
+// RunWithin is Run limited to vertices at distance at most maxDist from
+// source; every other vertex is left at Infinity. It passes the budget to
+// BMSSP as the global bound, so vertices beyond it are never settled.
+func (s *Solver) RunWithin(source int, maxDist float64) []float64 {
+	B := Infinity
+	if maxDist < Infinity {
+		// BMSSP completes vertices strictly below B; include maxDist itself
+		B = math.Nextafter(maxDist, Infinity)
+	}
+	s.run(s.one(source), B)
+
+	// Vertices past the budget may still hold tentative distances
+	for _, v := range s.touched {
+		if s.Dist[v] > maxDist {
+			s.Dist[v] = Infinity
+			s.Pred[v] = -1
+		}
+	}
 	return s.Dist
 }
 
+// run computes distances from the distinct vertices in sources below the
+// bound B.
+func (s *Solver) run(sources []int, B float64) []float64 {
+	if s.BaseCaseLimit < 1 {
+		panic(fmt.Sprintf("sssp: BaseCaseLimit is %d, must be at least 1", s.BaseCaseLimit))
+	}
+	s.startTiming()
+	s.resetState()
+	s.depth, s.maxDepth, s.relaxations = 0, 0, 0
+	s.settled, s.nextProgress = 0, progressStep(s.G.V)
+	s.stopped = false
+	s.startLog(sources, B)
+	for _, source := range sources {
+		s.Dist[source] = 0
+		s.touch(source)
+		s.listener.OnNodeDiscovered(-1, source, 0)
+	}
+
+	l := s.topLevel()
+	s.levelCounts = slices.Grow(s.levelCounts[:0], l+1)[:l+1]
+	clear(s.levelCounts)
+	s.blockSizes = slices.Grow(s.blockSizes[:0], l+1)[:l+1]
+	clear(s.blockSizes)
+
+	// Initial call
+	// S = sources, B = Infinity unless a budget was given
+	s.phaseChange("BMSSP", l)
+	s.BMSSP(l, B, sources)
+	s.stopTiming()
+	s.finishLog()
+
+	if s.progress != nil {
+		s.progress(s.settled, s.G.V)
+	}
+	return s.Dist
+}
+
+// topLevel returns the recursion depth of the initial BMSSP call,
+// l = ceil(log n / t), so that 2^(l*t) >= n. A single-vertex graph gets
+// l = 0 and is solved by BaseCase alone; any larger graph gets at least 1.
+func (s *Solver) topLevel() int {
+	if s.G.V <= 1 {
+		return 0
+	}
+	return int(math.Ceil(math.Log2(float64(s.G.V)) / float64(s.T)))
+}
+
+// RunInto is Run writing the distances into out instead of s.Dist, which is
+// left untouched. out must have length at least G.V; only out[:G.V] is used.
+// The first call with a given out fills it with Infinity. Calling again with
+// the same out, as in a loop, resets only what the previous run wrote, like
+// Run does with s.Dist, and allocates nothing once the solver's buffers have
+// grown, provided edges are relaxed sequentially (see SetWorkers).
+func (s *Solver) RunInto(source int, out []float64) error {
+	if len(out) < s.G.V {
+		return fmt.Errorf("sssp: output slice has length %d, need %d", len(out), s.G.V)
+	}
+	own := s.Dist
+	s.Dist = out[:s.G.V]
+	s.Run(source)
+	s.Dist = own
+	return nil
+}
+
+// one returns source as a one-element slice in reused storage.
+func (s *Solver) one(source int) []int {
+	s.bufInt = append(s.bufInt[:0], source)
+	return s.bufInt
+}
+
+// Warmup does a throwaway run from source so that later runs do not pay for
+// first-touch page faults and buffer growth. Call it once before a timing
+// loop. No events, progress or logs are reported, and afterwards the solver
+// is as if freshly created: every distance is Infinity and the run statistics
+// are cleared.
+func (s *Solver) Warmup(source int) {
+	listener, progress, logger := s.listener, s.progress, s.logger
+	s.listener, s.progress, s.logger = &NoOpListener{}, nil, nil
+	s.Run(source)
+	s.listener, s.progress, s.logger = listener, progress, logger
+
+	// Touch the generation stamps too, then reset every vertex
+	clear(s.gen)
+	s.curGen = 0
+	s.fullReset = true
+	s.resetState()
+
+	s.depth, s.maxDepth, s.settled = 0, 0, 0
+	s.timings = PhaseTimings{}
+	clear(s.levelCounts)
+	clear(s.blockSizes)
+}
+
+// MaxDepth returns how many levels of BMSSP recursion below the top-level
+// call the last run reached. It never exceeds l = ceil(log n / t).
+func (s *Solver) MaxDepth() int {
+	return s.maxDepth
+}
+
 // BMSSP (Bounded Multi-Source Shortest Path) - Algorithm 3
 func (s *Solver) BMSSP(l int, B float64, S []int) (float64, []int) {
-	s.listener.OnPhaseChange("BMSSP", l)
+	s.phaseChange("BMSSP", l)
+
+	if s.depth > s.maxDepth {
+		s.maxDepth = s.depth
+	}
+	s.depth++
+	defer func() { s.depth-- }()
 
 	if l == 0 {
-		s.listener.OnPhaseChange("BaseCase", 0)
+		s.phaseChange("BaseCase", 0)
+		prev := s.enterPhase(&s.timings.BaseCase)
+		defer s.leavePhase(prev)
 		return s.BaseCase(B, S)
 	}
 
-	s.listener.OnPhaseChange("FindPivots", l)
+	s.phaseChange("FindPivots", l)
+	prev := s.enterPhase(&s.timings.FindPivots)
 	P, W := s.FindPivots(B, S)
+	s.leavePhase(prev)
 
 	if len(P) == 0 {
-		return s.finalizeBMSSP(B, W, make(map[int]bool))
+		return s.finalizeBMSSP(l, B, W, s.levelSet(l))
 	}
 
-	D := s.initializeDataStructure(l, P)
-	U, _ := s.processMainLoop(l, B, D, W)
+	D := s.initializeDataStructure(l, B, P)
+	prev = s.enterPhase(&s.timings.MainLoop)
+	U, Bprime := s.processMainLoop(l, B, D)
+	s.leavePhase(prev)
 
-	return s.finalizeBMSSP(B, W, U)
+	return s.finalizeBMSSP(l, Bprime, W, U)
 }
 
-// initializeDataStructure creates and populates the data structure for BMSSP
-func (s *Solver) initializeDataStructure(l int, P []int) *ds.DataStructure {
+// initializeDataStructure resets and populates the level-l data structure for BMSSP
+func (s *Solver) initializeDataStructure(l int, B float64, P []int) Frontier {
 	M := int(math.Pow(2, float64((l-1)*s.T)))
 	if M < 1 {
 		M = 1
 	}
 
-	D := ds.NewDataStructure(M)
+	for len(s.blockSizes) <= l { // BMSSP called outside Run
+		s.blockSizes = append(s.blockSizes, 0)
+	}
+	s.blockSizes[l] = M
+
+	var D Frontier
+	if s.NewFrontier != nil {
+		D = s.NewFrontier(M, B)
+	} else {
+		d := s.levelFrontier(l, M)
+		d.B = B
+		D = d
+	}
 	for _, x := range P {
 		D.Insert(x, s.Dist[x])
 	}
@@ -162,47 +530,49 @@ func (s *Solver) initializeDataStructure(l int, P []int) *ds.DataStructure {
 	return D
 }
 
-// processMainLoop handles the main iteration loop of BMSSP
-func (s *Solver) processMainLoop(l int, B float64, D *ds.DataStructure, W []int) (map[int]bool, int) {
-	U := make(map[int]bool)
+// processMainLoop handles the main iteration loop of BMSSP. It returns the
+// settled set and the bound B' it is complete up to: B on success, or the
+// last B'_i when the workload limit cut the loop short.
+func (s *Solver) processMainLoop(l int, B float64, D Frontier) (*vertexSet, float64) {
+	U := s.levelSet(l)
 	limit := s.K * int(math.Pow(2, float64(l*s.T)))
+	Bprime := B
 
-	for len(U) < limit && D.Count > 0 {
+	for U.len() < limit && D.Len() > 0 && !s.stopped {
 		Si, Bi := s.pullAndExtract(D)
 		Bi_prime, Ui := s.BMSSP(l-1, Bi, Si)
+		s.listener.OnBoundUpdate(l, Bi, Bi_prime)
 
 		s.addToSet(U, Ui)
 		K := s.relaxEdges(Ui, Bi, Bi_prime, B, D)
 		s.batchPrepend(D, K, Si, Bi_prime, Bi)
 
-		if len(U) > limit {
-			return s.buildFinalSet(U, W, Bi_prime), limit
-		}
+		Bprime = math.Min(Bi_prime, B)
 	}
 
-	return U, limit
+	return U, Bprime
 }
 
 // pullAndExtract pulls items from data structure and extracts keys
-func (s *Solver) pullAndExtract(D *ds.DataStructure) ([]int, float64) {
+func (s *Solver) pullAndExtract(D Frontier) ([]int, float64) {
 	items, Bi := D.Pull()
-	// Return slice directly without copying - caller shouldn't modify
-	Si := make([]int, len(items))
-	for i, item := range items {
-		Si[i] = item.Key
+	sc := s.atDepth()
+	sc.S = sc.S[:0]
+	for _, item := range items {
+		sc.S = append(sc.S, item.Key)
 	}
-	return Si, Bi
+	return sc.S, Bi
 }
 
 // addToSet adds elements from Ui to U
-func (s *Solver) addToSet(U map[int]bool, Ui []int) {
+func (s *Solver) addToSet(U *vertexSet, Ui []int) {
 	for _, u := range Ui {
-		U[u] = true
+		U.add(u)
 	}
 }
 
 // relaxEdges performs edge relaxation and returns items for batch prepend
-func (s *Solver) relaxEdges(Ui []int, Bi, Bi_prime, B float64, D *ds.DataStructure) []ds.Item {
+func (s *Solver) relaxEdges(Ui []int, Bi, Bi_prime, B float64, D Frontier) []ds.Item {
 	if len(Ui) == 0 {
 		return nil
 	}
@@ -216,22 +586,22 @@ func (s *Solver) relaxEdges(Ui []int, Bi, Bi_prime, B float64, D *ds.DataStructu
 }
 
 // relaxEdgesSequential processes edges sequentially
-func (s *Solver) relaxEdgesSequential(Ui []int, Bi, Bi_prime, B float64, D *ds.DataStructure) []ds.Item {
-	var K []ds.Item
+func (s *Solver) relaxEdgesSequential(Ui []int, Bi, Bi_prime, B float64, D Frontier) []ds.Item {
+	K := s.bufItem[:0]
 
 	for _, u := range Ui {
+		if isUnreachable(s.Dist[u]) {
+			continue
+		}
 		for _, edge := range s.G.Adj[u] {
 			newDist := s.Dist[u] + edge.Weight
 
-			if newDist <= s.Dist[edge.To] {
+			// A settled vertex is final: at best this ties its distance
+			if !isUnreachable(newDist) && newDist <= s.Dist[edge.To] && !s.settledMark[edge.To] {
 				oldDist := s.Dist[edge.To]
 				s.Dist[edge.To] = newDist
 
-				if oldDist == Infinity {
-					s.listener.OnNodeDiscovered(edge.To, newDist)
-				} else {
-					s.listener.OnNodeRelaxed(u, edge.To, oldDist, newDist)
-				}
+				s.recordRelax(u, edge.To, oldDist, newDist)
 
 				if newDist >= Bi && newDist < B {
 					D.Insert(edge.To, newDist)
@@ -242,58 +612,215 @@ func (s *Solver) relaxEdgesSequential(Ui []int, Bi, Bi_prime, B float64, D *ds.D
 		}
 	}
 
+	s.bufItem = K
 	return K
 }
 
-// relaxEdgesParallel processes edges in parallel using worker pool
-func (s *Solver) relaxEdgesParallel(Ui []int, Bi, Bi_prime, B float64, D *ds.DataStructure) []ds.Item {
-	var wg sync.WaitGroup
-	results := make([][]ds.Item, len(Ui))
+// relaxCandidate is a relaxation proposed by a parallel worker.
+type relaxCandidate struct {
+	from, to int
+	dist     float64
+}
 
-	// Process each vertex in parallel
-	for i, u := range Ui {
-		wg.Add(1)
-		go func(vertexIdx, vertex int) {
-			defer wg.Done()
+// relaxEdgesParallel scans edges with one goroutine per chunk of Ui. Workers
+// only read distances; updates, inserts and listener callbacks are applied by
+// the calling goroutine so listeners never see concurrent calls.
+func (s *Solver) relaxEdgesParallel(Ui []int, Bi, Bi_prime, B float64, D Frontier) []ds.Item {
+	results := s.scanCandidates(Ui)
+
+	// Apply candidates sequentially
+	totalK := s.bufItem[:0]
+	for _, candidates := range results {
+		for _, c := range candidates {
+			if c.dist > s.Dist[c.to] {
+				continue // superseded by an earlier candidate
+			}
+			oldDist := s.Dist[c.to]
+			s.Dist[c.to] = c.dist
 
-			var localK []ds.Item
-			for _, edge := range s.G.Adj[vertex] {
-				newDist := s.Dist[vertex] + edge.Weight
+			s.recordRelax(c.from, c.to, oldDist, c.dist)
 
-				if newDist <= s.Dist[edge.To] {
-					oldDist := s.Dist[edge.To]
-					s.Dist[edge.To] = newDist
+			if c.dist >= Bi && c.dist < B {
+				D.Insert(c.to, c.dist)
+			} else if c.dist >= Bi_prime && c.dist < Bi {
+				totalK = append(totalK, ds.Item{Key: c.to, Value: c.dist})
+			}
+		}
+	}
 
-					if oldDist == Infinity {
-						s.listener.OnNodeDiscovered(edge.To, newDist)
-					} else {
-						s.listener.OnNodeRelaxed(vertex, edge.To, oldDist, newDist)
-					}
+	s.bufItem = totalK
+	return totalK
+}
+
+// scanCandidates splits Ui into at most numWorkers contiguous chunks and
+// collects, per chunk and in Ui order, every edge that would relax its target.
+func (s *Solver) scanCandidates(Ui []int) [][]relaxCandidate {
+	chunks := min(s.numWorkers, len(Ui))
+	size := (len(Ui) + chunks - 1) / chunks
+	results := make([][]relaxCandidate, chunks)
+
+	var wg sync.WaitGroup
+	for c := 0; c < chunks; c++ {
+		lo := c * size
+		hi := min(lo+size, len(Ui))
+		if lo >= hi {
+			break
+		}
 
-					if newDist >= Bi && newDist < B {
-						D.Insert(edge.To, newDist)
-					} else if newDist >= Bi_prime && newDist < Bi {
-						localK = append(localK, ds.Item{Key: edge.To, Value: newDist})
+		wg.Add(1)
+		s.workerPool <- struct{}{}
+		go func(c int, part []int) {
+			defer func() {
+				<-s.workerPool
+				wg.Done()
+			}()
+
+			var local []relaxCandidate
+			for _, u := range part {
+				if isUnreachable(s.Dist[u]) {
+					continue
+				}
+				for _, edge := range s.G.Adj[u] {
+					newDist := s.Dist[u] + edge.Weight
+					if !isUnreachable(newDist) && newDist <= s.Dist[edge.To] && !s.settledMark[edge.To] {
+						local = append(local, relaxCandidate{from: u, to: edge.To, dist: newDist})
 					}
 				}
 			}
-			results[vertexIdx] = localK
-		}(i, u)
+			results[c] = local
+		}(c, Ui[lo:hi])
 	}
-
 	wg.Wait()
 
-	// Merge results
-	var totalK []ds.Item
-	for _, k := range results {
-		totalK = append(totalK, k...)
+	return results
+}
+
+// resetState clears Dist, Pred and settledMark before a run. Only vertices
+// touched by the previous run are cleared, unless a full reset is pending or
+// the generation counter wraps around.
+func (s *Solver) resetState() {
+	s.curGen++
+	if s.curGen == 0 {
+		clear(s.gen)
+		s.curGen = 1
+		s.fullReset = true
 	}
 
-	return totalK
+	// The touched list describes the Dist of the previous run; RunInto may
+	// have swapped in a different buffer, whose contents are unknown.
+	sameDist := len(s.Dist) == 0 || len(s.touchedDist) == len(s.Dist) && &s.touchedDist[0] == &s.Dist[0]
+
+	if s.fullReset {
+		for i := range s.Dist {
+			s.Dist[i] = Infinity
+			s.Pred[i] = -1
+			s.settledMark[i] = false
+			s.levelMark[i] = false
+		}
+		s.fullReset = false
+	} else {
+		if !sameDist {
+			for i := range s.Dist {
+				s.Dist[i] = Infinity
+			}
+		}
+		for _, v := range s.touched {
+			s.Dist[v] = Infinity
+			s.Pred[v] = -1
+			s.settledMark[v] = false
+			s.levelMark[v] = false
+		}
+	}
+	s.touched = s.touched[:0]
+	s.touchedDist = s.Dist
+	for _, set := range s.sets {
+		if set != nil {
+			set.reset()
+		}
+	}
+}
+
+// touch records that v's state was written during the current run.
+func (s *Solver) touch(v int) {
+	if s.gen[v] != s.curGen {
+		s.gen[v] = s.curGen
+		s.touched = append(s.touched, v)
+	}
+}
+
+// markSettled counts u the first time it is settled in a run, reports its
+// tree edge, and reports progress each time the count crosses another step.
+// It also passes u to onSettle, setting stopped if that asks to end the run.
+func (s *Solver) markSettled(u int) {
+	if s.settledMark[u] {
+		return
+	}
+	s.settledMark[u] = true
+	s.settled++
+	if p := s.Pred[u]; p >= 0 {
+		s.listener.OnTreeEdge(p, u, s.edgeWeight(p, u))
+	}
+	if s.onSettle != nil && s.onSettle(u) {
+		s.stopped = true
+	}
+	if s.progress != nil && s.settled >= s.nextProgress {
+		s.progress(s.settled, s.G.V)
+		s.nextProgress += progressStep(s.G.V)
+	}
+}
+
+// countLevel credits u to level l the first time it joins any U this run.
+func (s *Solver) countLevel(l, u int) {
+	if s.levelMark[u] {
+		return
+	}
+	s.levelMark[u] = true
+	for len(s.levelCounts) <= l { // BMSSP or BaseCase called outside Run
+		s.levelCounts = append(s.levelCounts, 0)
+	}
+	s.levelCounts[l]++
+}
+
+// LevelCounts returns, for each BMSSP level l of the last run, how many
+// vertices first joined a completed set U at that level: settled by the
+// base case for l = 0, or completed by FindPivots and added when a level-l
+// call finalized U. The counts sum to the number of reachable vertices.
+// The slice is reused by the next run.
+func (s *Solver) LevelCounts() []int {
+	return s.levelCounts
+}
+
+// BlockSizes returns, for each BMSSP level l of the last run, the block size
+// M = 2^((l-1)t) of the data structure built at that level, or 0 where none
+// was built: always at level 0, which runs BaseCase, and at levels whose
+// calls all found no pivots. The slice is reused by the next run.
+func (s *Solver) BlockSizes() []int {
+	return s.blockSizes
+}
+
+// progressStep reports roughly every 1% of the vertices.
+func progressStep(n int) int {
+	return max(1, n/100)
+}
+
+// recordRelax records u as the predecessor of v and reports the event when
+// newDist improves on oldDist. Equal-distance relaxations are ignored so the
+// predecessor graph stays acyclic across zero-weight cycles.
+func (s *Solver) recordRelax(u, v int, oldDist, newDist float64) {
+	s.touch(v)
+	s.relaxations++
+	if newDist < oldDist {
+		s.Pred[v] = u
+	}
+	if isUnreachable(oldDist) {
+		s.listener.OnNodeDiscovered(u, v, newDist)
+	} else if newDist < oldDist {
+		s.listener.OnNodeRelaxed(u, v, oldDist, newDist)
+	}
 }
 
 // batchPrepend prepares and adds batch items to data structure
-func (s *Solver) batchPrepend(D *ds.DataStructure, K []ds.Item, Si []int, Bi_prime, Bi float64) {
+func (s *Solver) batchPrepend(D Frontier, K []ds.Item, Si []int, Bi_prime, Bi float64) {
 	// Reuse batch buffer
 	s.bufBatch = s.bufBatch[:0]
 	if cap(s.bufBatch) < len(K)+len(Si) {
@@ -311,88 +838,98 @@ func (s *Solver) batchPrepend(D *ds.DataStructure, K []ds.Item, Si []int, Bi_pri
 	D.BatchPrepend(s.bufBatch)
 }
 
-// buildFinalSet constructs the final vertex set with filtering
-func (s *Solver) buildFinalSet(U map[int]bool, W []int, bound float64) map[int]bool {
-	for _, w := range W {
-		if s.Dist[w] < bound && !U[w] {
-			U[w] = true
-		}
-	}
-	return U
-}
-
-// finalizeBMSSP converts the result set to final format
-func (s *Solver) finalizeBMSSP(B float64, W []int, U map[int]bool) (float64, []int) {
-	finalU := make([]int, 0, len(U))
-	for u := range U {
-		finalU = append(finalU, u)
-	}
+// finalizeBMSSP adds the vertices of W below B to U and converts the result
+// set to final format. U is emptied for reuse by the next call at level l.
+func (s *Solver) finalizeBMSSP(l int, B float64, W []int, U *vertexSet) (float64, []int) {
+	defer U.reset()
 
 	for _, w := range W {
-		if s.Dist[w] < B && !U[w] {
-			finalU = append(finalU, w)
+		if s.Dist[w] < B && U.add(w) {
+			s.countLevel(l, w)
 		}
 	}
 
-	return B, finalU
+	return B, s.result(U)
 }
 
 // FindPivots - Algorithm 1
+//
+// P and W are reused by the next FindPivots at the same recursion depth.
 func (s *Solver) FindPivots(B float64, S []int) ([]int, []int) {
-	inW := make([]bool, s.G.V)
+	sc := s.atDepth()
+	inW := s.inW
+	W_list := sc.W[:0]
 	for _, x := range S {
-		inW[x] = true
+		if !inW[x] {
+			inW[x] = true
+			W_list = append(W_list, x)
+		}
 	}
 
-	W_list := make([]int, len(S))
-	copy(W_list, S)
-
 	// Relax k steps
 	W_list = s.relaxKSteps(B, S, inW, W_list)
 
-	// If W grew too large, return early
+	var P []int
 	if len(W_list) > s.K*len(S) {
-		P := make([]int, len(S))
-		copy(P, S)
-		return P, W_list
+		// If W grew too large, every source is a pivot
+		P = append(sc.P[:0], S...)
+	} else {
+		// Compute pivots from tree sizes
+		P = s.computePivots(S, inW, sc.P[:0])
+	}
+	sc.P, sc.W = P, W_list
+
+	// Reset scratch space for the next call
+	for _, w := range W_list {
+		inW[w] = false
+		s.treeSize[w] = 0
 	}
 
-	// Compute pivots from tree sizes
-	P := s.computePivots(S, inW)
 	return P, W_list
 }
 
 // relaxKSteps performs k relaxation steps from source set
 func (s *Solver) relaxKSteps(B float64, S []int, inW []bool, W_list []int) []int {
 	Wi_prev := S
+	limit := s.K * len(S)
 
 	for i := 1; i <= s.K; i++ {
-		Wi := make([]int, 0)
+		// Alternate between two buffers; Wi_prev is the other one
+		Wi := s.layers[i%2][:0]
+		// layerMark[v] == layerStamp marks membership of Wi
+		s.layerStamp++
 
 		for _, u := range Wi_prev {
+			if isUnreachable(s.Dist[u]) {
+				continue
+			}
 			for _, edge := range s.G.Adj[u] {
 				newDist := s.Dist[u] + edge.Weight
 
-				if newDist < s.Dist[edge.To] {
+				// Equal distances still extend the layer: the vertex may
+				// have been reached earlier without being expanded here.
+				if !isUnreachable(newDist) && newDist <= s.Dist[edge.To] {
 					oldDist := s.Dist[edge.To]
 					s.Dist[edge.To] = newDist
 
-					if oldDist == Infinity {
-						s.listener.OnNodeDiscovered(edge.To, newDist)
-					} else {
-						s.listener.OnNodeRelaxed(u, edge.To, oldDist, newDist)
-					}
+					s.recordRelax(u, edge.To, oldDist, newDist)
 
-					if newDist < B && !inW[edge.To] {
+					// Once W is over the limit every source becomes a pivot and
+					// this is the last layer: keep relaxing, but stop growing W.
+					if newDist < B && len(W_list) <= limit && s.layerMark[edge.To] != s.layerStamp {
+						s.layerMark[edge.To] = s.layerStamp
 						Wi = append(Wi, edge.To)
-						inW[edge.To] = true
-						W_list = append(W_list, edge.To)
+						if !inW[edge.To] {
+							inW[edge.To] = true
+							W_list = append(W_list, edge.To)
+						}
 					}
 				}
 			}
 		}
 
-		if len(W_list) > s.K*len(S) {
+		s.layers[i%2] = Wi
+		if len(W_list) > limit {
 			return W_list
 		}
 		Wi_prev = Wi
@@ -401,15 +938,11 @@ func (s *Solver) relaxKSteps(B float64, S []int, inW []bool, W_list []int) []int
 	return W_list
 }
 
-// computePivots identifies pivots based on tree sizes
-func (s *Solver) computePivots(S []int, inW []bool) []int {
-	memoSize := make([]int, s.G.V)
-
-	calcSize := s.makeTreeSizeCalculator(inW, memoSize)
-
-	P := make([]int, 0)
+// computePivots appends to P the vertices of S whose trees have at least K
+// vertices.
+func (s *Solver) computePivots(S []int, inW []bool, P []int) []int {
 	for _, u := range S {
-		if calcSize(u) >= s.K {
+		if s.subtreeSize(u, inW) >= s.K {
 			P = append(P, u)
 		}
 	}
@@ -417,109 +950,162 @@ func (s *Solver) computePivots(S []int, inW []bool) []int {
 	return P
 }
 
-// makeTreeSizeCalculator creates a function to calculate tree sizes with cycle detection
-func (s *Solver) makeTreeSizeCalculator(inW []bool, memoSize []int) func(int) int {
-	var calcSize func(u int) int
-
-	calcSize = func(u int) int {
-		if memoSize[u] > 0 {
-			return memoSize[u]
-		}
-
-		if memoSize[u] == -1 {
-			return 1 // Cycle detected
-		}
-
-		memoSize[u] = -1
-		count := 1 + s.countTreeChildren(u, inW, calcSize)
-		memoSize[u] = count
-
-		return count
+// subtreeSize returns the size of u's tree in the shortest path forest over
+// W, memoized in treeSize; -1 marks a vertex in progress, which cuts cycles.
+func (s *Solver) subtreeSize(u int, inW []bool) int {
+	if s.treeSize[u] > 0 {
+		return s.treeSize[u]
 	}
 
-	return calcSize
-}
-
-// countTreeChildren counts children in the shortest path forest
-func (s *Solver) countTreeChildren(u int, inW []bool, calcSize func(int) int) int {
-	count := 0
+	if s.treeSize[u] == -1 {
+		return 1 // Cycle detected
+	}
 
+	s.treeSize[u] = -1
+	count := 1
 	for _, edge := range s.G.Adj[u] {
 		v := edge.To
-		if inW[v] && math.Abs(s.Dist[v]-(s.Dist[u]+edge.Weight)) < 1e-9 {
-			count += calcSize(v)
+		if inW[v] && s.tight(s.Dist[u]+edge.Weight, s.Dist[v]) {
+			count += s.subtreeSize(v, inW)
 		}
 	}
+	s.treeSize[u] = count
 
 	return count
 }
 
+// tight reports whether distance d reached over an edge matches dv within
+// Epsilon, relative to d so that large weights do not need a larger setting.
+func (s *Solver) tight(d, dv float64) bool {
+	return math.Abs(dv-d) <= s.Epsilon*math.Max(1, math.Abs(d))
+}
+
 // BaseCase - Algorithm 2
+//
+// Runs a bounded Dijkstra from the complete vertices in S. It settles at
+// least K+1 vertices below B before giving up, but never stops between two
+// vertices at the same distance: the zero-weight cycles introduced by the
+// degree transformation would otherwise leave B' equal to every settled
+// distance and the caller could make no progress.
 func (s *Solver) BaseCase(B float64, S []int) (float64, []int) {
-	U0 := make(map[int]bool)
-	pq := &PriorityQueue{}
-	heap.Init(pq)
+	U0 := s.levelSet(0)
+	pq := &s.pq
+	defer s.drainPQ()
+	defer U0.reset()
 
 	for _, x := range S {
-		U0[x] = true
-		heap.Push(pq, &PQItem{u: x, priority: s.Dist[x]})
+		if s.Dist[x] < B {
+			heap.Push(pq, s.getPQItem(x, s.Dist[x]))
+		}
 	}
 
-	limit := s.K + 1
+	limit := s.BaseCaseLimit
+	last := 0.0
+
+	for pq.Len() > 0 {
+		// Enough settled and the next candidate is strictly farther:
+		// every vertex below it has been found.
+		if U0.len() >= limit && (*pq)[0].priority > last {
+			return (*pq)[0].priority, s.result(U0)
+		}
 
-	for pq.Len() > 0 && len(U0) < limit {
 		item := heap.Pop(pq).(*PQItem)
-		u := item.u
+		u, priority := item.u, item.priority
+		s.putPQItem(item)
 
-		// If popped distance > current dist, ignore (stale)
-		if item.priority > s.Dist[u] {
+		// Skip settled vertices and stale entries
+		if U0.has(u) || priority > s.Dist[u] {
 			continue
 		}
 
-		U0[u] = true // Add to set
-		s.listener.OnIterationComplete(len(U0))
+		// Only entries below B are ever pushed, so u is settled within bound
+		U0.add(u)
+		s.countLevel(0, u)
+		last = s.Dist[u]
+		s.listener.OnNodeSettled(u, s.Dist[u])
+		s.listener.OnIterationComplete(U0.len())
+		s.markSettled(u)
+		if s.stopped {
+			// The run is being abandoned; the bound no longer matters
+			return last, s.result(U0)
+		}
 
+		if isUnreachable(s.Dist[u]) {
+			continue
+		}
 		for _, edge := range s.G.Adj[u] {
 			v := edge.To
-			w := edge.Weight
-			if s.Dist[u]+w <= s.Dist[v] && s.Dist[u]+w < B {
+			newDist := s.Dist[u] + edge.Weight
+			if !U0.has(v) && !s.settledMark[v] && !isUnreachable(newDist) && newDist <= s.Dist[v] && newDist < B {
 				oldDist := s.Dist[v]
-				s.Dist[v] = s.Dist[u] + w
+				s.Dist[v] = newDist
 
-				if oldDist == Infinity {
-					s.listener.OnNodeDiscovered(v, s.Dist[v])
-				} else {
-					s.listener.OnNodeRelaxed(u, v, oldDist, s.Dist[v])
-				}
+				s.recordRelax(u, v, oldDist, newDist)
 
-				heap.Push(pq, &PQItem{u: v, priority: s.Dist[v]})
+				heap.Push(pq, s.getPQItem(v, newDist))
 			}
 		}
 	}
 
-	uList := make([]int, 0, len(U0))
-	for u := range U0 {
-		uList = append(uList, u)
+	// Exhausted everything below B
+	return B, s.result(U0)
+}
+
+// drainPQ returns any entries left in the BaseCase heap to the pool.
+func (s *Solver) drainPQ() {
+	for i, item := range s.pq {
+		s.putPQItem(item)
+		s.pq[i] = nil
 	}
+	s.pq = s.pq[:0]
+}
 
-	if len(U0) <= s.K {
-		return B, uList
+// levelFrontier returns the data structure for BMSSP level l, emptied and
+// with block size m, allocating it on first use.
+func (s *Solver) levelFrontier(l, m int) *ds.DataStructure {
+	for len(s.frontiers) <= l {
+		s.frontiers = append(s.frontiers, nil)
 	}
+	if s.frontiers[l] == nil {
+		s.frontiers[l] = ds.NewDataStructure(m)
+	} else {
+		s.frontiers[l].Reset(m)
+	}
+	return s.frontiers[l]
+}
 
-	// Return max dist in U0 as B'
-	maxD := 0.0
-	for u := range U0 {
-		if s.Dist[u] > maxD {
-			maxD = s.Dist[u]
-		}
+// depthScratch holds the slices handed out by the BMSSP call at one
+// recursion depth: the keys it pulled, FindPivots' P and W, and the U it
+// returns. Only one call per depth is active, and each result is consumed
+// before the next call at that depth starts.
+type depthScratch struct {
+	S, P, W, U []int
+}
+
+// atDepth returns the buffers of the current recursion depth, allocating
+// them on first use.
+func (s *Solver) atDepth() *depthScratch {
+	for len(s.scratch) <= s.depth {
+		s.scratch = append(s.scratch, &depthScratch{})
 	}
+	return s.scratch[s.depth]
+}
 
-	// Filter U: {v in U0 : d[v] < B'}
-	finalU := make([]int, 0)
-	for u := range U0 {
-		if s.Dist[u] < maxD {
-			finalU = append(finalU, u)
-		}
+// result copies set into the current depth's U buffer and returns it.
+func (s *Solver) result(set *vertexSet) []int {
+	sc := s.atDepth()
+	sc.U = set.list(sc.U)
+	return sc.U
+}
+
+// levelSet returns the reusable U set for BMSSP level l, allocating it on
+// first use.
+func (s *Solver) levelSet(l int) *vertexSet {
+	for len(s.sets) <= l {
+		s.sets = append(s.sets, nil)
+	}
+	if s.sets[l] == nil {
+		s.sets[l] = newVertexSet(s.G.V)
 	}
-	return maxD, finalU
+	return s.sets[l]
 }
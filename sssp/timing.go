@@ -0,0 +1,72 @@
+package sssp
+
+import "time"
+
+// PhaseTimings is the wall-clock time a run spent in each phase. Phases are
+// exclusive: time in a nested BMSSP call counts toward the phase it runs, not
+// toward the main loop that called it, so the four phases add up to Total.
+// Progress callbacks after the search are not timed.
+// The graph transformation happens before Run and is not included.
+type PhaseTimings struct {
+	FindPivots time.Duration
+	BaseCase   time.Duration
+	MainLoop   time.Duration // pulls, relaxations and batch prepends
+	Other      time.Duration // setup, data structure init and finalizing U
+	Total      time.Duration
+}
+
+// EnableTiming makes subsequent runs record PhaseTimings, see Timings.
+func (s *Solver) EnableTiming() {
+	s.timing = true
+}
+
+// Timings returns the phase timings of the last run. It is zero unless
+// EnableTiming was called before that run.
+func (s *Solver) Timings() PhaseTimings {
+	return s.timings
+}
+
+// startTiming resets the timings and starts charging time to Other.
+func (s *Solver) startTiming() {
+	if !s.timing {
+		return
+	}
+	s.timings = PhaseTimings{}
+	s.phase = &s.timings.Other
+	s.phaseStart = time.Now()
+	s.runStart = s.phaseStart
+}
+
+// stopTiming charges the time since the last switch and fills in Total.
+func (s *Solver) stopTiming() {
+	if !s.timing {
+		return
+	}
+	s.leavePhase(nil)
+	s.timings.Total = s.phaseStart.Sub(s.runStart)
+}
+
+// enterPhase charges the time since the last switch to the current phase and
+// makes d current. It returns the previous phase for leavePhase.
+func (s *Solver) enterPhase(d *time.Duration) *time.Duration {
+	if !s.timing {
+		return nil
+	}
+	prev := s.phase
+	s.leavePhase(d)
+	return prev
+}
+
+// leavePhase charges the time since the last switch to the current phase and
+// makes prev current again.
+func (s *Solver) leavePhase(prev *time.Duration) {
+	if !s.timing {
+		return
+	}
+	now := time.Now()
+	if s.phase != nil {
+		*s.phase += now.Sub(s.phaseStart)
+	}
+	s.phase = prev
+	s.phaseStart = now
+}
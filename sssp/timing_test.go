@@ -0,0 +1,46 @@
+package sssp
+
+import (
+	"testing"
+	"time"
+
+	"github.com/phr3nzy/duan-sssp/gen"
+)
+
+func TestPhaseTimings(t *testing.T) {
+	tg := gen.Random(5000, 20000, 3).ToConstantDegree()
+	solver := NewSolver(tg.G)
+
+	solver.Run(tg.OriginalTo[0])
+	if got := solver.Timings(); got != (PhaseTimings{}) {
+		t.Fatalf("timings recorded without EnableTiming: %+v", got)
+	}
+
+	solver.EnableTiming()
+	start := time.Now()
+	solver.Run(tg.OriginalTo[0])
+	elapsed := time.Since(start)
+
+	pt := solver.Timings()
+	for name, d := range map[string]time.Duration{
+		"FindPivots": pt.FindPivots,
+		"BaseCase":   pt.BaseCase,
+		"MainLoop":   pt.MainLoop,
+		"Other":      pt.Other,
+	} {
+		if d < 0 {
+			t.Errorf("%s = %v, want >= 0", name, d)
+		}
+	}
+	if pt.BaseCase == 0 || pt.FindPivots == 0 {
+		t.Errorf("expected time in both FindPivots and BaseCase: %+v", pt)
+	}
+
+	sum := pt.FindPivots + pt.BaseCase + pt.MainLoop + pt.Other
+	if sum != pt.Total {
+		t.Errorf("phases sum to %v, Total is %v", sum, pt.Total)
+	}
+	if pt.Total > elapsed || pt.Total < elapsed/2 {
+		t.Errorf("Total = %v, measured run took %v", pt.Total, elapsed)
+	}
+}
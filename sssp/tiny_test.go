@@ -0,0 +1,50 @@
+package sssp
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/graph"
+)
+
+// TestTinyGraphs checks every edge set over 1 to 3 vertices, self-loops
+// included, from every source, both on the raw graph and through the
+// constant-degree transform. At these sizes log n is at most 1.6, so K and T
+// are clamped and the top level is 0 or 1.
+func TestTinyGraphs(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for n := 1; n <= 3; n++ {
+		for mask := 0; mask < 1<<(n*n); mask++ {
+			g := graph.NewGraph(n)
+			for i := 0; i < n*n; i++ {
+				if mask&(1<<i) != 0 {
+					g.AddEdge(i/n, i%n, float64(rng.Intn(3)))
+				}
+			}
+
+			for source := 0; source < n; source++ {
+				want := Dijkstra(g, source)
+				raw := NewSolver(g).Run(source)
+				mapped := ShortestPaths(g, source)
+				for v := range want {
+					if raw[v] != want[v] || mapped[v] != want[v] {
+						t.Fatalf("n=%d mask=%b source=%d: dist[%d] raw %v, transformed %v, want %v",
+							n, mask, source, v, raw[v], mapped[v], want[v])
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestTinyGraphLevels(t *testing.T) {
+	for n, want := range map[int]int{1: 0, 2: 1, 3: 1, 4: 1, 5: 2} {
+		s := NewSolver(graph.NewGraph(n))
+		if s.K != 2 || s.T != 2 {
+			t.Errorf("n=%d: K=%d T=%d, want both clamped to 2", n, s.K, s.T)
+		}
+		if got := s.topLevel(); got != want {
+			t.Errorf("n=%d: top level %d, want %d", n, got, want)
+		}
+	}
+}
@@ -0,0 +1,110 @@
+package sssp
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// Trace event kinds
+const (
+	TraceDiscover  = "discover"
+	TraceRelax     = "relax"
+	TraceSettle    = "settle"
+	TracePhase     = "phase"
+	TraceIteration = "iteration"
+	TraceBound     = "bound"
+	TraceTreeEdge  = "tree"
+)
+
+// TraceEvent is a single recorded solver event. Only the fields relevant to
+// Kind are meaningful, but the numeric ones are always written: vertex 0 and
+// distance 0 are ordinary values that a consumer replaying the JSON must see.
+// Seq increases by one for every event in a run.
+type TraceEvent struct {
+	Seq     uint64  `json:"seq"`
+	Kind    string  `json:"kind"`
+	From    int     `json:"from"`
+	To      int     `json:"to"`
+	OldDist float64 `json:"oldDist"`
+	Dist    float64 `json:"dist"`
+	Phase   string  `json:"phase,omitempty"`
+	Level   int     `json:"level"`
+	Settled int     `json:"settled"`
+	Bi      float64 `json:"bi"`
+	BiPrime float64 `json:"biPrime"`
+	Weight  float64 `json:"weight"`
+}
+
+// Trace is the ordered event log of a run.
+type Trace struct {
+	Events []TraceEvent `json:"events"`
+}
+
+// WriteJSON serializes the trace as a single JSON document.
+func (t *Trace) WriteJSON(w io.Writer) error {
+	return json.NewEncoder(w).Encode(t)
+}
+
+// DistancesAt replays discover and relax events up to and including seq and
+// returns the distance array of an n-vertex graph at that point.
+func (t *Trace) DistancesAt(n int, seq uint64) []float64 {
+	dist := make([]float64, n)
+	for i := range dist {
+		dist[i] = Infinity
+	}
+
+	for _, e := range t.Events {
+		if e.Seq > seq {
+			break
+		}
+		if e.Kind == TraceDiscover || e.Kind == TraceRelax {
+			dist[e.To] = e.Dist
+		}
+	}
+	return dist
+}
+
+// TraceListener records every event it receives into Trace.
+type TraceListener struct {
+	Trace Trace
+	seq   uint64
+}
+
+// NewTraceListener creates an empty trace recorder.
+func NewTraceListener() *TraceListener {
+	return &TraceListener{}
+}
+
+func (tl *TraceListener) record(e TraceEvent) {
+	tl.seq++
+	e.Seq = tl.seq
+	tl.Trace.Events = append(tl.Trace.Events, e)
+}
+
+func (tl *TraceListener) OnNodeDiscovered(from, to int, dist float64) {
+	tl.record(TraceEvent{Kind: TraceDiscover, From: from, To: to, OldDist: Infinity, Dist: dist})
+}
+
+func (tl *TraceListener) OnNodeRelaxed(from, to int, oldDist, newDist float64) {
+	tl.record(TraceEvent{Kind: TraceRelax, From: from, To: to, OldDist: oldDist, Dist: newDist})
+}
+
+func (tl *TraceListener) OnNodeSettled(node int, dist float64) {
+	tl.record(TraceEvent{Kind: TraceSettle, To: node, Dist: dist})
+}
+
+func (tl *TraceListener) OnPhaseChange(phase string, level int) {
+	tl.record(TraceEvent{Kind: TracePhase, Phase: phase, Level: level})
+}
+
+func (tl *TraceListener) OnIterationComplete(settled int) {
+	tl.record(TraceEvent{Kind: TraceIteration, Settled: settled})
+}
+
+func (tl *TraceListener) OnBoundUpdate(level int, Bi, BiPrime float64) {
+	tl.record(TraceEvent{Kind: TraceBound, Level: level, Bi: Bi, BiPrime: BiPrime})
+}
+
+func (tl *TraceListener) OnTreeEdge(parent, child int, weight float64) {
+	tl.record(TraceEvent{Kind: TraceTreeEdge, From: parent, To: child, Weight: weight})
+}
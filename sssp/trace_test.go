@@ -0,0 +1,91 @@
+package sssp
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/gen"
+	"github.com/phr3nzy/duan-sssp/graph"
+)
+
+// TestTraceReplay reconstructs the final distances from a JSON-serialized trace
+func TestTraceReplay(t *testing.T) {
+	g := gen.Random(300, 900, 1)
+	tg := g.ToConstantDegree()
+	solver := NewSolver(tg.G)
+	tl := NewTraceListener()
+	solver.SetEventListener(tl)
+
+	dist := solver.Run(tg.OriginalTo[0])
+
+	var buf bytes.Buffer
+	if err := tl.Trace.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+
+	var decoded Trace
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("decoding trace: %v", err)
+	}
+	if len(decoded.Events) != len(tl.Trace.Events) {
+		t.Fatalf("decoded %d events, recorded %d", len(decoded.Events), len(tl.Trace.Events))
+	}
+
+	for i, e := range decoded.Events {
+		if e.Seq != uint64(i+1) {
+			t.Fatalf("event %d has seq %d, want %d", i, e.Seq, i+1)
+		}
+	}
+
+	last := decoded.Events[len(decoded.Events)-1].Seq
+	replayed := decoded.DistancesAt(tg.G.V, last)
+	for v := range dist {
+		if replayed[v] != dist[v] {
+			t.Errorf("vertex %d: replayed %v, Run returned %v", v, replayed[v], dist[v])
+		}
+	}
+
+	// Before any event every vertex is unreached
+	for v, d := range decoded.DistancesAt(tg.G.V, 0) {
+		if d != Infinity {
+			t.Errorf("vertex %d reached before first event: %v", v, d)
+		}
+	}
+}
+
+// TestTraceSourceEventJSON checks that the discover event of vertex 0 at
+// distance 0 keeps its zero fields in the raw JSON.
+func TestTraceSourceEventJSON(t *testing.T) {
+	g := graph.NewGraph(2)
+	g.AddEdge(0, 1, 1)
+	solver := NewSolver(g)
+	tl := NewTraceListener()
+	solver.SetEventListener(tl)
+	solver.Run(0)
+
+	var buf bytes.Buffer
+	if err := tl.Trace.WriteJSON(&buf); err != nil {
+		t.Fatalf("WriteJSON: %v", err)
+	}
+	var raw struct {
+		Events []map[string]interface{} `json:"events"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &raw); err != nil {
+		t.Fatalf("decoding trace: %v", err)
+	}
+
+	first := raw.Events[0]
+	if first["kind"] != TraceDiscover {
+		t.Fatalf("first event kind = %v, want %q", first["kind"], TraceDiscover)
+	}
+	want := map[string]float64{"from": -1, "to": 0, "dist": 0}
+	for key, v := range want {
+		got, ok := first[key]
+		if !ok {
+			t.Errorf("source discover event has no %q field: %v", key, first)
+		} else if got != v {
+			t.Errorf("source discover event %q = %v, want %v", key, got, v)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package sssp
+
+import "github.com/phr3nzy/duan-sssp/graph"
+
+// TransformedSolver is a Solver on a transformed graph that takes and returns
+// original vertex indices, so the OriginalTo and MapDistances steps cannot
+// be skipped by mistake. The embedded Solver has the transform set (see
+// SetTransform) and can still be used directly on transformed nodes.
+type TransformedSolver struct {
+	*Solver
+	TG *graph.TransformedGraph
+}
+
+// NewTransformedSolver creates a solver for tg.G with tg as its transform.
+func NewTransformedSolver(tg *graph.TransformedGraph) *TransformedSolver {
+	s := NewSolver(tg.G)
+	s.SetTransform(tg)
+	return &TransformedSolver{Solver: s, TG: tg}
+}
+
+// Solve runs from original vertex source and returns a new slice of
+// distances indexed by original vertices. It panics if source is not a
+// vertex of the original graph.
+func (ts *TransformedSolver) Solve(source int) []float64 {
+	return ts.TG.MapDistances(ts.Run(ts.TG.StartNode(source)))
+}
+
+// Distance returns the distance between original vertices source and target
+// and whether target is reachable; (Infinity, false) if either is not a
+// vertex of the original graph. The solve stops early once target's distance
+// is certified, as RunNearest does for a single target, so vertices farther
+// than target are mostly left unsettled.
+func (ts *TransformedSolver) Distance(source, target int) (float64, bool) {
+	n := len(ts.TG.OriginalTo)
+	if source < 0 || source >= n || target < 0 || target >= n {
+		return Infinity, false
+	}
+	t := ts.TG.OriginalTo[target]
+	res := ts.RunNearest(ts.TG.StartNode(source), map[int]bool{t: true}, 1)
+	if len(res) == 0 {
+		return Infinity, false
+	}
+	return res[0].Dist, true
+}
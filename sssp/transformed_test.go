@@ -0,0 +1,27 @@
+package sssp
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/gen"
+)
+
+func TestTransformedSolverSolve(t *testing.T) {
+	g := gen.Random(500, 2000, 6)
+	tg := g.ToConstantDegree()
+	ts := NewTransformedSolver(tg)
+	manual := NewSolver(tg.G)
+
+	for _, k := range []int{0, 1, 250, 499} {
+		want := tg.MapDistances(manual.Run(tg.OriginalTo[k]))
+		got := ts.Solve(k)
+		if !slices.Equal(got, want) {
+			t.Errorf("Solve(%d) differs from the manual pipeline", k)
+		}
+	}
+
+	// The embedded solver reports the tree over original vertices
+	dist := ts.Solve(3)
+	checkTree(t, ts.ShortestPathTree(), dist, 3)
+}
@@ -0,0 +1,123 @@
+package sssp
+
+import (
+	"slices"
+
+	"github.com/phr3nzy/duan-sssp/graph"
+)
+
+// SetTransform tells the solver that G was produced by tg.ToConstantDegree,
+// so results such as ShortestPathTree are reported on the original vertices.
+func (s *Solver) SetTransform(tg *graph.TransformedGraph) {
+	s.tg = tg
+}
+
+// ShortestPathTree returns the shortest-path tree of the last run, with an
+// edge from each vertex's parent to the vertex weighted as in the input graph.
+// Unreachable vertices have no parent. With a transform set (see SetTransform)
+// the tree is over the original vertices, otherwise over G.
+func (s *Solver) ShortestPathTree() *graph.Graph {
+	if s.tg == nil {
+		tree := graph.NewGraph(s.G.V)
+		for v, u := range s.Pred {
+			if u >= 0 {
+				tree.AddEdge(u, v, s.edgeWeight(u, v))
+			}
+		}
+		return tree
+	}
+
+	tree := graph.NewGraph(len(s.tg.OriginalTo))
+	for v, start := range s.tg.OriginalTo {
+		// Walk back through v's zero-weight cycle to the node entered by a
+		// real edge; its predecessor lies in the parent's cycle.
+		x := start
+		for s.Pred[x] >= 0 && s.tg.NewToOrigin[s.Pred[x]] == v {
+			x = s.Pred[x]
+		}
+		if y := s.Pred[x]; y >= 0 {
+			tree.AddEdge(s.tg.NewToOrigin[y], v, s.edgeWeight(y, x))
+		}
+	}
+	return tree
+}
+
+// ShortestPathDAG returns every edge of the last run that lies on some
+// shortest path: each u->v, with reachable u, whose dist[u]+w matches dist[v]
+// within Epsilon. Counting paths through it from the source counts shortest
+// paths. Self-loops are left out; it is acyclic unless the input has a
+// zero-weight cycle. As with ShortestPathTree, a transform set by
+// SetTransform reports it over the original vertices, dropping the cycles'
+// internal edges.
+func (s *Solver) ShortestPathDAG() *graph.Graph {
+	origin := func(x int) int { return x }
+	n := s.G.V
+	if s.tg != nil {
+		origin = func(x int) int { return s.tg.NewToOrigin[x] }
+		n = len(s.tg.OriginalTo)
+	}
+
+	dag := graph.NewGraph(n)
+	for x, adj := range s.G.Adj {
+		if isUnreachable(s.Dist[x]) {
+			continue
+		}
+		u := origin(x)
+		for _, e := range adj {
+			v := origin(e.To)
+			if u != v && s.tight(s.Dist[x]+e.Weight, s.Dist[e.To]) {
+				dag.AddEdge(u, v, e.Weight)
+			}
+		}
+	}
+	return dag
+}
+
+// Paths returns a shortest path from the last run's source to each of
+// targets, read off the shared Pred array. Every path starts at the source
+// and ends at its target; an unreachable target maps to nil. Each walk stops
+// at the source, so the total cost is the size of the result. As with
+// ShortestPathTree, a transform set by SetTransform means targets and paths
+// are original vertices, each cycle's nodes collapsed into one.
+func (s *Solver) Paths(targets []int) map[int][]int {
+	origin := func(x int) int { return x }
+	start := origin
+	if s.tg != nil {
+		origin = func(x int) int { return s.tg.NewToOrigin[x] }
+		start = s.tg.StartNode
+	}
+
+	paths := make(map[int][]int, len(targets))
+	for _, target := range targets {
+		if _, done := paths[target]; done {
+			continue
+		}
+		x := start(target)
+		if isUnreachable(s.Dist[x]) {
+			paths[target] = nil
+			continue
+		}
+		var path []int
+		for ; x >= 0; x = s.Pred[x] {
+			if v := origin(x); len(path) == 0 || path[len(path)-1] != v {
+				path = append(path, v)
+			}
+		}
+		slices.Reverse(path)
+		paths[target] = path
+	}
+	return paths
+}
+
+// edgeWeight returns the cheapest weight among the edges u->v of G. For a
+// tree edge, Pred[v] == u, it is the weight that gives v its distance: a
+// cheaper parallel edge would have given v a shorter one.
+func (s *Solver) edgeWeight(u, v int) float64 {
+	w := Infinity
+	for _, e := range s.G.Adj[u] {
+		if e.To == v && e.Weight < w {
+			w = e.Weight
+		}
+	}
+	return w
+}
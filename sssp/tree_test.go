@@ -0,0 +1,209 @@
+package sssp
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/gen"
+	"github.com/phr3nzy/duan-sssp/graph"
+)
+
+// checkTree verifies that tree has reachable-1 edges, at most one parent per
+// vertex, and that summing weights from the root reproduces dist.
+func checkTree(t *testing.T, tree *graph.Graph, dist []float64, source int) {
+	t.Helper()
+
+	parent := make([]int, tree.V)
+	weight := make([]float64, tree.V)
+	for i := range parent {
+		parent[i] = -1
+	}
+	edges := 0
+	for u := range tree.Adj {
+		for _, e := range tree.Adj[u] {
+			if parent[e.To] != -1 {
+				t.Fatalf("vertex %d has two parents", e.To)
+			}
+			parent[e.To] = u
+			weight[e.To] = e.Weight
+			edges++
+		}
+	}
+
+	reachable := 0
+	for v, d := range dist {
+		if d == Infinity {
+			if parent[v] != -1 {
+				t.Errorf("unreachable vertex %d has parent %d", v, parent[v])
+			}
+			continue
+		}
+		reachable++
+
+		cost := 0.0
+		steps := 0
+		for x := v; x != source; x = parent[x] {
+			if parent[x] == -1 || steps > tree.V {
+				t.Fatalf("vertex %d: tree path does not reach the source", v)
+			}
+			cost += weight[x]
+			steps++
+		}
+		if math.Abs(cost-d) > 1e-9 {
+			t.Errorf("vertex %d: tree path cost %v, distance %v", v, cost, d)
+		}
+	}
+
+	if edges != reachable-1 {
+		t.Errorf("tree has %d edges, want %d", edges, reachable-1)
+	}
+}
+
+func TestShortestPathTree(t *testing.T) {
+	g := gen.Random(300, 900, 1)
+	tg := g.ToConstantDegree()
+	solver := NewSolver(tg.G)
+	solver.SetTransform(tg)
+	dist := tg.MapDistances(solver.Run(tg.OriginalTo[0]))
+
+	checkTree(t, solver.ShortestPathTree(), dist, 0)
+}
+
+func TestShortestPathTreeUntransformed(t *testing.T) {
+	g := gen.Random(300, 900, 1)
+	tg := g.ToConstantDegree()
+	solver := NewSolver(tg.G)
+	source := tg.OriginalTo[0]
+	dist := solver.Run(source)
+
+	checkTree(t, solver.ShortestPathTree(), dist, source)
+}
+
+// TestShortestPathTreeTies uses small integer weights, including zero, so many
+// vertices have several shortest paths.
+func TestShortestPathTreeTies(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	g := graph.NewGraph(400)
+	for i := 0; i < 2000; i++ {
+		g.AddEdge(rng.Intn(400), rng.Intn(400), float64(rng.Intn(4)))
+	}
+	tg := g.ToConstantDegree()
+	solver := NewSolver(tg.G)
+	solver.SetTransform(tg)
+	dist := tg.MapDistances(solver.Run(tg.OriginalTo[7]))
+
+	checkTree(t, solver.ShortestPathTree(), dist, 7)
+}
+
+// TestShortestPathDAG uses a grid of unit edges, where vertex (i, j) has
+// C(i+j, i) shortest paths from the corner, plus a costlier shortcut and a
+// zero-weight self-loop that must both be left out.
+func TestShortestPathDAG(t *testing.T) {
+	const n = 4
+	id := func(i, j int) int { return i*n + j }
+	g := graph.NewGraph(n * n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i+1 < n {
+				g.AddEdge(id(i, j), id(i+1, j), 1)
+			}
+			if j+1 < n {
+				g.AddEdge(id(i, j), id(i, j+1), 1)
+			}
+		}
+	}
+	g.AddEdge(id(0, 0), id(1, 1), 2.5)
+	g.AddEdge(id(2, 2), id(2, 2), 0)
+
+	tg := g.ToConstantDegree()
+	transformed := NewSolver(tg.G)
+	transformed.SetTransform(tg)
+	transformed.Run(tg.StartNode(0))
+	plain := NewSolver(g)
+	plain.RunUntransformed(0)
+
+	for name, dag := range map[string]*graph.Graph{
+		"transformed":   transformed.ShortestPathDAG(),
+		"untransformed": plain.ShortestPathDAG(),
+	} {
+		if got, want := dag.NumEdges(), 2*n*(n-1); got != want {
+			t.Errorf("%s: %d tight edges, want %d", name, got, want)
+		}
+		for _, e := range dag.Adj[id(0, 0)] {
+			if e.To == id(1, 1) {
+				t.Errorf("%s: shortcut is not tight but is in the DAG", name)
+			}
+		}
+		if dag.OutDegree(id(2, 2)) != 2 {
+			t.Errorf("%s: out-degree of (2, 2) = %d, want 2", name, dag.OutDegree(id(2, 2)))
+		}
+
+		// Vertex ids increase along every edge, so id order is topological
+		paths := make([]int, n*n)
+		paths[0] = 1
+		for u := range dag.Adj {
+			for _, e := range dag.Adj[u] {
+				paths[e.To] += paths[u]
+			}
+		}
+		if paths[id(n-1, n-1)] != 20 {
+			t.Errorf("%s: %d shortest paths to the far corner, want C(6, 3) = 20", name, paths[id(n-1, n-1)])
+		}
+	}
+}
+
+// TestPaths checks that every path Paths returns runs from the source to its
+// target over edges of g and costs the target's distance, for both a
+// transformed and a plain solver.
+func TestPaths(t *testing.T) {
+	g := gen.Random(300, 700, 1)
+	tg := g.ToConstantDegree()
+	transformed := NewSolver(tg.G)
+	transformed.SetTransform(tg)
+	dist := tg.MapDistances(transformed.Run(tg.StartNode(0)))
+	plain := NewSolver(g)
+	plain.RunUntransformed(0)
+
+	targets := make([]int, 0, g.V+1)
+	for v := 0; v < g.V; v++ {
+		targets = append(targets, v)
+	}
+	targets = append(targets, 5) // repeats are harmless
+
+	for name, paths := range map[string]map[int][]int{
+		"transformed":   transformed.Paths(targets),
+		"untransformed": plain.Paths(targets),
+	} {
+		if len(paths) != g.V {
+			t.Fatalf("%s: %d paths, want %d", name, len(paths), g.V)
+		}
+		for v, path := range paths {
+			if isUnreachable(dist[v]) {
+				if path != nil {
+					t.Fatalf("%s: unreachable %d has path %v", name, v, path)
+				}
+				continue
+			}
+			if path[0] != 0 || path[len(path)-1] != v {
+				t.Fatalf("%s: path %v does not run from 0 to %d", name, path, v)
+			}
+			cost := 0.0
+			for i := 1; i < len(path); i++ {
+				w := Infinity
+				for _, e := range g.Adj[path[i-1]] {
+					if e.To == path[i] {
+						w = min(w, e.Weight)
+					}
+				}
+				if isUnreachable(w) {
+					t.Fatalf("%s: path %v uses missing edge %d->%d", name, path, path[i-1], path[i])
+				}
+				cost += w
+			}
+			if math.Abs(cost-dist[v]) > 1e-9*max(1, dist[v]) {
+				t.Fatalf("%s: path to %d costs %v, want %v", name, v, cost, dist[v])
+			}
+		}
+	}
+}
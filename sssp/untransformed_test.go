@@ -0,0 +1,50 @@
+package sssp
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/gen"
+	"github.com/phr3nzy/duan-sssp/graph"
+)
+
+func TestRunUntransformedMatchesDijkstra(t *testing.T) {
+	for seed := int64(0); seed < 40; seed++ {
+		rng := rand.New(rand.NewSource(seed))
+		n := 2 + rng.Intn(1000)
+		g := graph.NewGraph(n)
+		for i := 0; i < n*(1+rng.Intn(4)); i++ {
+			g.AddEdge(rng.Intn(n), rng.Intn(n), float64(rng.Intn(50)))
+		}
+		// A few hubs with edges to and from a large share of the graph
+		for h := 0; h < 1+rng.Intn(3); h++ {
+			hub := rng.Intn(n)
+			for v := 0; v < n; v += 1 + rng.Intn(3) {
+				g.AddEdge(hub, v, float64(rng.Intn(100)))
+				g.AddEdge(v, hub, float64(rng.Intn(100)))
+			}
+		}
+
+		source := rng.Intn(n)
+		got := NewSolver(g).RunUntransformed(source)
+		want := Dijkstra(g, source)
+		for v := range want {
+			if got[v] != want[v] {
+				t.Fatalf("seed %d: dist[%d] = %v, want %v", seed, v, got[v], want[v])
+			}
+		}
+	}
+}
+
+func TestRunUntransformedRejectsTransform(t *testing.T) {
+	tg := gen.Random(50, 150, 1).ToConstantDegree()
+	solver := NewSolver(tg.G)
+	solver.SetTransform(tg)
+
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for a solver with a transform set")
+		}
+	}()
+	solver.RunUntransformed(tg.StartNode(0))
+}
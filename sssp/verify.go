@@ -0,0 +1,48 @@
+package sssp
+
+import (
+	"fmt"
+
+	"github.com/phr3nzy/duan-sssp/graph"
+)
+
+// Verify checks that dist is consistent with shortest paths from source in g,
+// without recomputing them. It requires dist[source] == 0, no edge (u, v, w)
+// with finite dist[u] and dist[v] > dist[u]+w, and for every other finite
+// vertex an incoming edge that attains its distance. It returns an error
+// naming the first violation, or nil.
+func Verify(g *graph.Graph, source int, dist []float64) error {
+	if len(dist) != g.V {
+		return fmt.Errorf("sssp: distance array has length %d, graph has %d vertices", len(dist), g.V)
+	}
+	if dist[source] != 0 {
+		return fmt.Errorf("sssp: dist[source=%d] = %v, want 0", source, dist[source])
+	}
+
+	tight := make([]bool, g.V)
+	tight[source] = true
+	for u := 0; u < g.V; u++ {
+		if isUnreachable(dist[u]) {
+			continue
+		}
+		for _, e := range g.Adj[u] {
+			d := dist[u] + e.Weight
+			if dist[e.To] > d {
+				return fmt.Errorf("sssp: edge %d->%d (weight %v) violates dist[%d] = %v > %v", u, e.To, e.Weight, e.To, dist[e.To], d)
+			}
+			if dist[e.To] == d {
+				tight[e.To] = true
+			}
+		}
+	}
+
+	for v, d := range dist {
+		if d != d || d < 0 {
+			return fmt.Errorf("sssp: dist[%d] = %v is not a valid distance", v, d)
+		}
+		if !isUnreachable(d) && !tight[v] {
+			return fmt.Errorf("sssp: dist[%d] = %v is not attained by any incoming edge", v, d)
+		}
+	}
+	return nil
+}
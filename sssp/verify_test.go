@@ -0,0 +1,55 @@
+package sssp
+
+import (
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/gen"
+	"github.com/phr3nzy/duan-sssp/graph"
+)
+
+func TestVerify(t *testing.T) {
+	g := gen.Random(500, 2000, 4)
+	dist := ShortestPaths(g, 0)
+	if err := Verify(g, 0, dist); err != nil {
+		t.Fatalf("correct run rejected: %v", err)
+	}
+
+	// Pick a reached vertex with an outgoing edge to a reached vertex.
+	u, v := -1, -1
+	for x := 1; x < g.V && u < 0; x++ {
+		for _, e := range g.Adj[x] {
+			if dist[x] < Infinity && e.To != 0 {
+				u, v = x, e.To
+				break
+			}
+		}
+	}
+	if u < 0 {
+		t.Fatal("no usable edge in test graph")
+	}
+
+	corrupt := func(name string, mutate func(d []float64)) {
+		d := append([]float64(nil), dist...)
+		mutate(d)
+		if err := Verify(g, 0, d); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	corrupt("too large", func(d []float64) { d[v] = d[u] + 1e6 })
+	corrupt("unreached despite finite predecessor", func(d []float64) { d[v] = Infinity })
+	corrupt("source nonzero", func(d []float64) { d[0] = 1 })
+
+	if err := Verify(g, 0, dist[:g.V-1]); err == nil {
+		t.Error("short array: expected error")
+	}
+}
+
+// TestVerifyUnattainedDistance catches an underestimate that still satisfies
+// every edge's inequality.
+func TestVerifyUnattainedDistance(t *testing.T) {
+	g := graph.NewGraph(2)
+	g.AddEdge(0, 1, 2)
+	if err := Verify(g, 0, []float64{0, 1}); err == nil {
+		t.Error("expected error for dist[1] = 1 with only a weight-2 edge")
+	}
+}
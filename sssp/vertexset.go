@@ -0,0 +1,46 @@
+package sssp
+
+// vertexSet is a set of vertex IDs backed by a membership array and the list
+// of members in insertion order. Unlike a map it costs one byte per graph
+// vertex up front and a single int per member, and reset is proportional to
+// the number of members rather than the graph size.
+type vertexSet struct {
+	mark []bool
+	ids  []int
+}
+
+func newVertexSet(n int) *vertexSet {
+	return &vertexSet{mark: make([]bool, n)}
+}
+
+// add inserts v and reports whether it was not already present.
+func (vs *vertexSet) add(v int) bool {
+	if vs.mark[v] {
+		return false
+	}
+	vs.mark[v] = true
+	vs.ids = append(vs.ids, v)
+	return true
+}
+
+func (vs *vertexSet) has(v int) bool {
+	return vs.mark[v]
+}
+
+func (vs *vertexSet) len() int {
+	return len(vs.ids)
+}
+
+// list copies the members into dst, reusing its storage, so the set can be
+// reset without losing them.
+func (vs *vertexSet) list(dst []int) []int {
+	return append(dst[:0], vs.ids...)
+}
+
+// reset empties the set, keeping its storage.
+func (vs *vertexSet) reset() {
+	for _, v := range vs.ids {
+		vs.mark[v] = false
+	}
+	vs.ids = vs.ids[:0]
+}
@@ -0,0 +1,46 @@
+package sssp
+
+import (
+	"container/heap"
+
+	"github.com/phr3nzy/duan-sssp/graph"
+)
+
+// Walk returns an iterator yielding the vertices reachable from source in
+// non-decreasing order of distance, each exactly once. Work happens lazily:
+// each call settles one more vertex with Dijkstra. After the last reachable
+// vertex it returns ok=false.
+func Walk(g *graph.Graph, source int) func() (vertex int, dist float64, ok bool) {
+	distTo := make([]float64, g.V)
+	for i := range distTo {
+		distTo[i] = Infinity
+	}
+	settled := make([]bool, g.V)
+	distTo[source] = 0
+
+	pq := &PriorityQueue{}
+	heap.Push(pq, &PQItem{u: source, priority: 0})
+
+	return func() (int, float64, bool) {
+		for pq.Len() > 0 {
+			item := heap.Pop(pq).(*PQItem)
+			u := item.u
+
+			// Skip stale entries
+			if settled[u] || item.priority > distTo[u] {
+				continue
+			}
+			settled[u] = true
+
+			for _, edge := range g.Adj[u] {
+				newDist := distTo[u] + edge.Weight
+				if newDist < distTo[edge.To] {
+					distTo[edge.To] = newDist
+					heap.Push(pq, &PQItem{u: edge.To, priority: newDist})
+				}
+			}
+			return u, distTo[u], true
+		}
+		return -1, Infinity, false
+	}
+}
@@ -0,0 +1,44 @@
+package sssp
+
+import (
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/gen"
+)
+
+func TestWalk(t *testing.T) {
+	g := gen.Random(500, 1200, 3)
+	want := Dijkstra(g, 0)
+
+	next := Walk(g, 0)
+	seen := make(map[int]bool)
+	last := 0.0
+	for {
+		v, d, ok := next()
+		if !ok {
+			break
+		}
+		if seen[v] {
+			t.Fatalf("vertex %d yielded twice", v)
+		}
+		seen[v] = true
+		if d < last {
+			t.Fatalf("vertex %d at %v after %v", v, d, last)
+		}
+		if d != want[v] {
+			t.Errorf("vertex %d: yielded %v, want %v", v, d, want[v])
+		}
+		last = d
+	}
+
+	for v, d := range want {
+		if (d != Infinity) != seen[v] {
+			t.Errorf("vertex %d: reachable %v, yielded %v", v, d != Infinity, seen[v])
+		}
+	}
+
+	// Exhausted iterators stay exhausted
+	if _, _, ok := next(); ok {
+		t.Error("iterator yielded after exhaustion")
+	}
+}
@@ -0,0 +1,43 @@
+package sssp
+
+import (
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/gen"
+)
+
+func TestWarmupLeavesCleanState(t *testing.T) {
+	tg := gen.Random(1000, 4000, 12).ToConstantDegree()
+	solver := NewSolver(tg.G)
+	solver.EnableTiming()
+	rec := &recordingListener{}
+	solver.SetEventListener(rec)
+	progressCalls := 0
+	solver.SetProgress(func(int, int) { progressCalls++ })
+
+	solver.Warmup(tg.OriginalTo[0])
+
+	if len(rec.edges) != 0 || progressCalls != 0 {
+		t.Errorf("warmup reported %d events and %d progress calls", len(rec.edges), progressCalls)
+	}
+	for v := range solver.Dist {
+		if solver.Dist[v] != Infinity || solver.Pred[v] != -1 {
+			t.Fatalf("after warmup: dist[%d] = %v, pred = %d", v, solver.Dist[v], solver.Pred[v])
+		}
+	}
+	if solver.MaxDepth() != 0 || solver.Timings() != (PhaseTimings{}) {
+		t.Errorf("warmup statistics not cleared: depth %d, timings %+v", solver.MaxDepth(), solver.Timings())
+	}
+
+	// The next run, from another source, must match a fresh solver
+	got := solver.Run(tg.OriginalTo[7])
+	want := NewSolver(tg.G).Run(tg.OriginalTo[7])
+	for v := range want {
+		if got[v] != want[v] {
+			t.Fatalf("after warmup: dist[%d] = %v, want %v", v, got[v], want[v])
+		}
+	}
+	if len(rec.edges) == 0 || progressCalls == 0 {
+		t.Error("listener and progress not restored after warmup")
+	}
+}
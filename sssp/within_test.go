@@ -0,0 +1,35 @@
+package sssp
+
+import (
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/gen"
+)
+
+func TestRunWithin(t *testing.T) {
+	g := gen.Random(1000, 4000, 2)
+	full := Dijkstra(g, 0)
+	tg := g.ToConstantDegree()
+	solver := NewSolver(tg.G)
+
+	for _, maxDist := range []float64{0, 50, full[g.Adj[0][0].To], 150, 400, Infinity} {
+		got := tg.MapDistances(solver.RunWithin(tg.OriginalTo[0], maxDist))
+		for v := range full {
+			want := full[v]
+			if want > maxDist {
+				want = Infinity
+			}
+			if got[v] != want {
+				t.Fatalf("maxDist %v: dist[%d] = %v, want %v", maxDist, v, got[v], want)
+			}
+		}
+	}
+
+	// A later unbounded run must not see the cutoff
+	got := tg.MapDistances(solver.Run(tg.OriginalTo[0]))
+	for v := range full {
+		if got[v] != full[v] {
+			t.Fatalf("after RunWithin: dist[%d] = %v, want %v", v, got[v], full[v])
+		}
+	}
+}
@@ -0,0 +1,143 @@
+package sssp
+
+import (
+	"fmt"
+	"runtime"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/gen"
+)
+
+func TestSetWorkersMatchesSequential(t *testing.T) {
+	g := gen.Random(2000, 8000, 2)
+	tg := g.ToConstantDegree()
+	source := tg.OriginalTo[0]
+
+	sequential := NewSolverSequential(tg.G)
+	want := slices.Clone(sequential.Run(source))
+
+	for _, n := range []int{1, 2, 4, 16} {
+		solver := NewSolver(tg.G)
+		solver.SetWorkers(n)
+		if got := solver.Run(source); !slices.Equal(got, want) {
+			t.Errorf("SetWorkers(%d): distances differ from the sequential run", n)
+		}
+	}
+}
+
+// TestSequentialAndParallelIdentical compares a NewSolverSequential run with
+// a four-worker run on several sources. Only distances are compared: U is
+// built from maps, so the order of intermediate events and the choice among
+// equal-distance predecessors vary between any two runs, parallel or not.
+func TestSequentialAndParallelIdentical(t *testing.T) {
+	tg := gen.Random(3000, 12000, 8).ToConstantDegree()
+	seq := NewSolverSequential(tg.G)
+	if seq.numWorkers != 1 {
+		t.Fatalf("NewSolverSequential: numWorkers = %d", seq.numWorkers)
+	}
+	par := NewSolver(tg.G)
+	par.SetWorkers(4)
+
+	for _, src := range []int{0, 1, 1500, 2999} {
+		source := tg.OriginalTo[src]
+		want := slices.Clone(seq.Run(source))
+		if got := par.Run(source); !slices.Equal(got, want) {
+			t.Errorf("source %d: parallel distances differ from sequential", src)
+		}
+	}
+}
+
+func TestSetWorkersBoundsPool(t *testing.T) {
+	solver := NewSolver(gen.Random(10, 20, 1))
+	solver.SetWorkers(3)
+	if cap(solver.workerPool) != 3 || solver.numWorkers != 3 {
+		t.Errorf("SetWorkers(3): pool capacity %d, numWorkers %d", cap(solver.workerPool), solver.numWorkers)
+	}
+	solver.SetWorkers(0)
+	if solver.numWorkers < 1 || cap(solver.workerPool) != solver.numWorkers {
+		t.Errorf("SetWorkers(0): pool capacity %d, numWorkers %d", cap(solver.workerPool), solver.numWorkers)
+	}
+}
+
+// scanPerVertex is the previous scan strategy, one goroutine per vertex of
+// Ui, kept to benchmark against scanCandidates.
+func scanPerVertex(s *Solver, Ui []int) [][]relaxCandidate {
+	var wg sync.WaitGroup
+	results := make([][]relaxCandidate, len(Ui))
+	for i, u := range Ui {
+		wg.Add(1)
+		go func(i, u int) {
+			defer wg.Done()
+			var local []relaxCandidate
+			for _, edge := range s.G.Adj[u] {
+				newDist := s.Dist[u] + edge.Weight
+				if newDist < Infinity && newDist <= s.Dist[edge.To] {
+					local = append(local, relaxCandidate{from: u, to: edge.To, dist: newDist})
+				}
+			}
+			results[i] = local
+		}(i, u)
+	}
+	wg.Wait()
+	return results
+}
+
+// BenchmarkRelaxScan compares per-vertex goroutines with chunked scanning
+// over 16K frontier vertices of a 100K-vertex graph after transformation.
+func BenchmarkRelaxScan(b *testing.B) {
+	g := gen.Random(100000, 300000, 1)
+	tg := g.ToConstantDegree()
+	solver := NewSolver(tg.G)
+	solver.SetWorkers(8)
+	solver.Run(tg.OriginalTo[0])
+
+	Ui := make([]int, 16384)
+	for i := range Ui {
+		Ui[i] = i * (tg.G.V / len(Ui))
+	}
+
+	b.Run("PerVertex", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			scanPerVertex(solver, Ui)
+		}
+	})
+	b.Run("Chunked", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			solver.scanCandidates(Ui)
+		}
+	})
+}
+
+// BenchmarkRelaxParallelism runs the same graphs with sequential relaxation
+// and with one worker per CPU, plus a fixed four workers so the parallel path
+// is measured even on a single CPU. Comparing the rows shows where, if
+// anywhere, relaxEdgesParallel starts to pay for its goroutines.
+func BenchmarkRelaxParallelism(b *testing.B) {
+	workers := []int{1, 4}
+	if n := runtime.NumCPU(); n != 1 && n != 4 {
+		workers = append(workers, n)
+	}
+
+	for _, v := range []int{1000, 10000, 100000} {
+		tg := gen.Random(v, 4*v, 1).ToConstantDegree()
+		source := tg.OriginalTo[0]
+
+		for _, w := range workers {
+			b.Run(fmt.Sprintf("V=%d/Workers=%d", v, w), func(b *testing.B) {
+				solver := NewSolver(tg.G)
+				solver.SetWorkers(w)
+				solver.Warmup(source)
+
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					solver.Run(source)
+				}
+			})
+		}
+	}
+}
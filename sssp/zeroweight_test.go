@@ -0,0 +1,38 @@
+package sssp
+
+import (
+	"testing"
+
+	"github.com/phr3nzy/duan-sssp/graph"
+)
+
+// zeroCycleGraph is one n-vertex cycle of zero-weight edges with a weighted
+// chord from every vertex, so every vertex is at distance 0 from every other
+// and ties are everywhere.
+func zeroCycleGraph(n int) *graph.Graph {
+	g := graph.NewGraph(n)
+	for v := 0; v < n; v++ {
+		g.AddEdge(v, (v+1)%n, 0)
+		g.AddEdge(v, (v*7+3)%n, float64(v%5))
+	}
+	return g
+}
+
+func TestZeroWeightCycleRelaxations(t *testing.T) {
+	g := zeroCycleGraph(20000)
+	tg := g.ToConstantDegree()
+	solver := NewSolver(tg.G)
+	dist := tg.MapDistances(solver.Run(tg.OriginalTo[0]))
+
+	for v, d := range dist {
+		if d != 0 {
+			t.Fatalf("dist[%d] = %v, want 0", v, d)
+		}
+	}
+
+	edges := tg.G.NumEdges()
+	t.Logf("%d relaxations for %d vertices, %d edges", solver.relaxations, tg.G.V, edges)
+	if solver.relaxations > 2*edges {
+		t.Errorf("%d relaxations, want at most 2x the %d edges", solver.relaxations, edges)
+	}
+}